@@ -0,0 +1,417 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package amplitudetest implements a fake Amplitude server for tests.
+//
+//	srv := amplitudetest.NewServer()
+//	defer srv.Close()
+//
+//	client := amplitude.New("key", amplitude.WithURL(srv.HTTPAPIURL()))
+//
+//	...
+//
+//	srv.EventuallyReceived(t, "user.created", "user-1")
+package amplitudetest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+)
+
+// Paths of the endpoints of the server.
+const (
+	HTTPAPIPath     = "/2/httpapi"
+	BatchPath       = "/batch"
+	IdentifyPath    = "/identify"
+	UserMapPath     = "/usermap"
+	AttributionPath = "/attribution"
+)
+
+var errMissingAPIKey = errors.New("missing api_key")
+
+// IdentifyEventType is the event type of the identifications recorded by the server.
+const IdentifyEventType = "$identify"
+
+// DefaultTimeout of EventuallyReceived.
+const DefaultTimeout = time.Second * 5
+
+// Request received by the server.
+type Request struct {
+	Path        string
+	ContentType string
+	Body        []byte
+	StatusCode  int
+}
+
+// Server is a fake of the HTTP V2, batch, identify, usermap and attribution APIs.
+// The events of successful requests are recorded, see Events.
+type Server struct {
+	*httptest.Server
+
+	mtx          sync.Mutex
+	requests     []*Request
+	events       []*amplitude.Event
+	mappings     []*amplitude.UserMapping
+	attributions []*amplitude.Attribution
+	responses    []*Response
+}
+
+// NewServer starts a server, it must be closed by the caller.
+func NewServer() *Server {
+	s := &Server{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+HTTPAPIPath, s.handleEvents)
+	mux.HandleFunc("POST "+BatchPath, s.handleEvents)
+	mux.HandleFunc("POST "+IdentifyPath, s.handleIdentify)
+	mux.HandleFunc("POST "+UserMapPath, s.handleUserMap)
+	mux.HandleFunc("POST "+AttributionPath, s.handleAttribution)
+
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// HTTPAPIURL returns the URL of the HTTP V2 API, see amplitude.WithURL.
+func (s *Server) HTTPAPIURL() string {
+	return s.URL + HTTPAPIPath
+}
+
+// BatchURL returns the URL of the batch API, see amplitude.WithURL.
+func (s *Server) BatchURL() string {
+	return s.URL + BatchPath
+}
+
+// IdentifyURL returns the URL of the identify API.
+func (s *Server) IdentifyURL() string {
+	return s.URL + IdentifyPath
+}
+
+// UserMapURL returns the URL of the usermap API, see amplitude.WithUserMapURL.
+func (s *Server) UserMapURL() string {
+	return s.URL + UserMapPath
+}
+
+// AttributionURL returns the URL of the attribution API, see amplitude.WithAttributionURL.
+func (s *Server) AttributionURL() string {
+	return s.URL + AttributionPath
+}
+
+// Options returns the client options sending everything to the server.
+func (s *Server) Options() []amplitude.Option {
+	return []amplitude.Option{
+		amplitude.WithURL(s.HTTPAPIURL()),
+		amplitude.WithUserMapURL(s.UserMapURL()),
+		amplitude.WithAttributionURL(s.AttributionURL()),
+	}
+}
+
+// Respond queues responses returned, in order, to the next requests instead of a success.
+func (s *Server) Respond(responses ...*Response) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.responses = append(s.responses, responses...)
+}
+
+// Requests returns the requests received.
+func (s *Server) Requests() []*Request {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return append([]*Request(nil), s.requests...)
+}
+
+// Events returns the events received, in order, identifications included.
+func (s *Server) Events() []*amplitude.Event {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return append([]*amplitude.Event(nil), s.events...)
+}
+
+// Mappings returns the user mappings received.
+func (s *Server) Mappings() []*amplitude.UserMapping {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return append([]*amplitude.UserMapping(nil), s.mappings...)
+}
+
+// Attributions returns the attributions received.
+func (s *Server) Attributions() []*amplitude.Attribution {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return append([]*amplitude.Attribution(nil), s.attributions...)
+}
+
+// Reset forgets the requests, the recorded data and the scripted responses.
+func (s *Server) Reset() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.requests = nil
+	s.events = nil
+	s.mappings = nil
+	s.attributions = nil
+	s.responses = nil
+}
+
+// Find returns the received events matching match.
+func (s *Server) Find(match func(event *amplitude.Event) bool) []*amplitude.Event {
+	var events []*amplitude.Event
+
+	for _, event := range s.Events() {
+		if match(event) {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// EventuallyReceived waits DefaultTimeout for an event of eventType for
+// userID, any user when empty, and returns it. The test fails on timeout.
+func (s *Server) EventuallyReceived(t testing.TB, eventType string, userID string) *amplitude.Event {
+	t.Helper()
+
+	return s.EventuallyReceivedWithin(t, DefaultTimeout, eventType, userID)
+}
+
+// EventuallyReceivedWithin is EventuallyReceived with a timeout.
+func (s *Server) EventuallyReceivedWithin(t testing.TB, timeout time.Duration, eventType string, userID string) *amplitude.Event {
+	t.Helper()
+
+	match := func(event *amplitude.Event) bool {
+		return event.EventType == eventType && (userID == "" || event.UserID == userID)
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		if events := s.Find(match); len(events) > 0 {
+			return events[0]
+		}
+
+		if time.Now().After(deadline) {
+			t.Errorf("amplitudetest: no %q event received for user %q within %s", eventType, userID, timeout)
+
+			return nil
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+// receive records the request and returns the scripted response, nil on success.
+func (s *Server) receive(r *http.Request) (*Request, *Response, error) {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read request body failed: %w", err)
+	}
+
+	req := &Request{
+		Path:        r.URL.Path,
+		ContentType: r.Header.Get("Content-Type"),
+		Body:        b,
+		StatusCode:  http.StatusOK,
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.requests = append(s.requests, req)
+
+	if len(s.responses) == 0 {
+		return req, nil, nil
+	}
+
+	var resp *Response
+
+	resp, s.responses = s.responses[0], s.responses[1:]
+
+	req.StatusCode = resp.StatusCode
+
+	return req, resp, nil
+}
+
+// handle writes the scripted response or calls record and writes a success.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request, record func(req *Request) (int, error)) {
+	req, resp, err := s.receive(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if resp != nil {
+		writeResponse(w, resp)
+
+		return
+	}
+
+	n, err := record(req)
+	if err != nil {
+		s.mtx.Lock()
+		req.StatusCode = http.StatusBadRequest
+		s.mtx.Unlock()
+
+		writeResponse(w, &Response{
+			StatusCode: http.StatusBadRequest,
+			Body: map[string]interface{}{
+				"code":  http.StatusBadRequest,
+				"error": err.Error(),
+			},
+		})
+
+		return
+	}
+
+	writeResponse(w, &Response{
+		StatusCode: http.StatusOK,
+		Body: map[string]interface{}{
+			"code":               http.StatusOK,
+			"events_ingested":    n,
+			"payload_size_bytes": len(req.Body),
+			"server_upload_time": time.Now().UnixMilli(),
+		},
+	})
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	s.handle(w, r, func(req *Request) (int, error) {
+		payload := &amplitude.RequestPayload{}
+
+		if err := json.Unmarshal(req.Body, payload); err != nil {
+			return 0, fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		if payload.APIKey == "" {
+			return 0, errMissingAPIKey
+		}
+
+		s.mtx.Lock()
+		s.events = append(s.events, payload.Events...)
+		s.mtx.Unlock()
+
+		return len(payload.Events), nil
+	})
+}
+
+// formValue decodes the JSON value of field of a form encoded body into out.
+func formValue(req *Request, field string, out interface{}) error {
+	if !strings.HasPrefix(req.ContentType, "application/x-www-form-urlencoded") {
+		return fmt.Errorf("unexpected content type %q", req.ContentType)
+	}
+
+	form, err := parseForm(req.Body)
+	if err != nil {
+		return err
+	}
+
+	if form.Get("api_key") == "" {
+		return errMissingAPIKey
+	}
+
+	if err := json.Unmarshal([]byte(form.Get(field)), out); err != nil {
+		return fmt.Errorf("invalid %s: %w", field, err)
+	}
+
+	return nil
+}
+
+func (s *Server) handleIdentify(w http.ResponseWriter, r *http.Request) {
+	s.handle(w, r, func(req *Request) (int, error) {
+		var events []*amplitude.Event
+
+		if err := formValue(req, "identification", &events); err != nil {
+			event := &amplitude.Event{}
+
+			if err := formValue(req, "identification", event); err != nil {
+				return 0, err
+			}
+
+			events = []*amplitude.Event{event}
+		}
+
+		for _, event := range events {
+			event.EventType = IdentifyEventType
+		}
+
+		s.mtx.Lock()
+		s.events = append(s.events, events...)
+		s.mtx.Unlock()
+
+		return len(events), nil
+	})
+}
+
+func (s *Server) handleUserMap(w http.ResponseWriter, r *http.Request) {
+	s.handle(w, r, func(req *Request) (int, error) {
+		var mappings []*amplitude.UserMapping
+
+		if err := formValue(req, "mapping", &mappings); err != nil {
+			return 0, err
+		}
+
+		s.mtx.Lock()
+		s.mappings = append(s.mappings, mappings...)
+		s.mtx.Unlock()
+
+		return len(mappings), nil
+	})
+}
+
+func (s *Server) handleAttribution(w http.ResponseWriter, r *http.Request) {
+	s.handle(w, r, func(req *Request) (int, error) {
+		attribution := &amplitude.Attribution{}
+
+		if err := formValue(req, "event", attribution); err != nil {
+			return 0, err
+		}
+
+		s.mtx.Lock()
+		s.attributions = append(s.attributions, attribution)
+		s.mtx.Unlock()
+
+		return 1, nil
+	})
+}
+
+func parseForm(body []byte) (url.Values, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid form: %w", err)
+	}
+
+	return form, nil
+}
+
+func writeResponse(w http.ResponseWriter, resp *Response) {
+	if s, ok := resp.Body.(string); ok {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(resp.StatusCode)
+
+		_, _ = io.WriteString(w, s)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+
+	_ = json.NewEncoder(w).Encode(resp.Body)
+}
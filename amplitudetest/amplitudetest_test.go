@@ -0,0 +1,221 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitudetest
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient(srv *Server, opts ...amplitude.Option) amplitude.Client {
+	opts = append(srv.Options(), opts...)
+	opts = append(opts, amplitude.WithInterval(time.Millisecond*10), amplitude.WithRetryInterval(time.Millisecond))
+
+	return amplitude.New("key", opts...)
+}
+
+func TestServerEvents(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	c := newClient(srv)
+	defer c.Close()
+
+	assert.NoError(t, c.Enqueue(&amplitude.Event{EventType: "user.created", UserID: "user-1"}))
+	assert.NoError(t, c.Enqueue(&amplitude.Event{EventType: "user.created", UserID: "user-2"}))
+
+	event := srv.EventuallyReceived(t, "user.created", "user-2")
+	assert.Equal(t, "user-2", event.UserID)
+
+	assert.Len(t, srv.Find(func(event *amplitude.Event) bool {
+		return event.EventType == "user.created"
+	}), 2)
+
+	requests := srv.Requests()
+	assert.NotEmpty(t, requests)
+	assert.Equal(t, HTTPAPIPath, requests[0].Path)
+	assert.Equal(t, http.StatusOK, requests[0].StatusCode)
+
+	srv.Reset()
+
+	assert.Empty(t, srv.Events())
+	assert.Empty(t, srv.Requests())
+}
+
+// fakeTB records the failures of the assertion helpers.
+type fakeTB struct {
+	testing.TB
+
+	failed bool
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Errorf(string, ...interface{}) {
+	tb.failed = true
+}
+
+func TestServerEventuallyReceivedTimeout(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	tb := &fakeTB{}
+
+	assert.Nil(t, srv.EventuallyReceivedWithin(tb, time.Millisecond*20, "user.created", ""))
+	assert.True(t, tb.failed)
+}
+
+func TestServerRespond(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.Respond(
+		TooManyRequests(map[string]int{"user-1": 31}),
+		ServerError(http.StatusBadGateway),
+	)
+
+	var (
+		wg  sync.WaitGroup
+		err error
+	)
+
+	wg.Add(1)
+
+	c := newClient(srv, amplitude.WithCallback(func(payload *amplitude.Payload, e error) {
+		defer wg.Done()
+
+		err = e
+	}))
+	defer c.Close()
+
+	assert.NoError(t, c.Enqueue(&amplitude.Event{EventType: "user.created", UserID: "user-1"}))
+
+	wg.Wait()
+
+	// The event is sent on the third attempt.
+	assert.NoError(t, err)
+	assert.Len(t, srv.Events(), 1)
+
+	requests := srv.Requests()
+	assert.Len(t, requests, 3)
+	assert.Equal(t, http.StatusTooManyRequests, requests[0].StatusCode)
+	assert.Equal(t, http.StatusBadGateway, requests[1].StatusCode)
+	assert.Equal(t, http.StatusOK, requests[2].StatusCode)
+}
+
+func TestServerRespondErrors(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	var (
+		wg   sync.WaitGroup
+		errs []error
+	)
+
+	srv.Respond(BadRequest("api_key"), PayloadTooLarge(), InvalidEvents(map[string][]int{"time": {0}}))
+
+	wg.Add(3)
+
+	c := newClient(srv, amplitude.WithMaxRetry(0), amplitude.WithBatchSize(1), amplitude.WithCallback(func(payload *amplitude.Payload, err error) {
+		defer wg.Done()
+
+		errs = append(errs, err)
+	}))
+	defer c.Close()
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, c.Enqueue(&amplitude.Event{EventType: "user.created", UserID: "user-1"}))
+	}
+
+	wg.Wait()
+
+	codes := make([]int, 0, len(errs))
+
+	for _, err := range errs {
+		var errorResponse *amplitude.ErrorResponse
+
+		assert.True(t, errors.As(err, &errorResponse))
+
+		codes = append(codes, errorResponse.Code)
+	}
+
+	assert.Equal(t, []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusBadRequest}, codes)
+	assert.Empty(t, srv.Events())
+}
+
+func TestServerMappingsAndAttributions(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	c := newClient(srv)
+
+	assert.NoError(t, c.(amplitude.UserMapper).Map(&amplitude.UserMapping{UserID: "user-1", GlobalUserID: "global-1"}))
+	assert.NoError(t, c.(amplitude.Attributor).Attribute(&amplitude.Attribution{EventType: "Install", Platform: "ios", IDFA: "idfa-1"}))
+	assert.NoError(t, c.Close())
+
+	assert.Equal(t, []*amplitude.UserMapping{{UserID: "user-1", GlobalUserID: "global-1"}}, srv.Mappings())
+	assert.Equal(t, []*amplitude.Attribution{{EventType: "Install", Platform: "ios", IDFA: "idfa-1"}}, srv.Attributions())
+}
+
+func TestServerIdentify(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	for _, identification := range []string{
+		`[{"user_id":"user-1","user_properties":{"plan":"premium"}}]`,
+		`{"user_id":"user-2"}`,
+	} {
+		resp, err := http.PostForm(srv.IdentifyURL(), url.Values{
+			"api_key":        {"key"},
+			"identification": {identification},
+		})
+		assert.NoError(t, err)
+
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	event := srv.EventuallyReceived(t, IdentifyEventType, "user-1")
+	assert.Equal(t, "premium", event.UserProperties["plan"])
+
+	srv.EventuallyReceived(t, IdentifyEventType, "user-2")
+
+	resp, err := http.PostForm(srv.IdentifyURL(), url.Values{"identification": {`{"user_id":"user-1"}`}})
+	assert.NoError(t, err)
+
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestServerBatchInvalid(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	for _, body := range []string{`{`, `{"events":[]}`} {
+		resp, err := http.Post(srv.BatchURL(), "application/json", strings.NewReader(body))
+		assert.NoError(t, err)
+
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err := http.Post(srv.UserMapURL(), "application/json", strings.NewReader(`{}`))
+	assert.NoError(t, err)
+
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
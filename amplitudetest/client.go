@@ -0,0 +1,205 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitudetest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/euskadi31/go-amplitude/internal/properties"
+)
+
+var (
+	_ amplitude.ContextClient = (*Client)(nil)
+	_ amplitude.UserMapper    = (*Client)(nil)
+	_ amplitude.Attributor    = (*Client)(nil)
+)
+
+// Client is an in-memory amplitude.Client recording the enqueued events,
+// user mappings and attributions. Once closed it returns amplitude.ErrClosed.
+type Client struct {
+	mtx          sync.Mutex
+	err          error
+	closed       bool
+	events       []*amplitude.Event
+	mappings     []*amplitude.UserMapping
+	attributions []*amplitude.Attribution
+}
+
+// NewClient returns a recording client.
+func NewClient() *Client {
+	return &Client{}
+}
+
+// SetError makes the next calls return err instead of recording, until it is reset with nil.
+func (c *Client) SetError(err error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.err = err
+}
+
+func (c *Client) check() error {
+	if c.closed {
+		return amplitude.ErrClosed
+	}
+
+	return c.err
+}
+
+// Enqueue records event.
+func (c *Client) Enqueue(event *amplitude.Event) error {
+	return c.EnqueueContext(context.Background(), event)
+}
+
+// EnqueueContext records event, with the event properties carried by ctx like the amplitude client.
+func (c *Client) EnqueueContext(ctx context.Context, event *amplitude.Event) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if err := c.check(); err != nil {
+		return err
+	}
+
+	// The event of the caller is left untouched.
+	event = event.Clone()
+
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().UTC().Unix()
+	}
+
+	event.EventProperties = properties.Merge(event.EventProperties, amplitude.EventPropertiesFromContext(ctx))
+
+	c.events = append(c.events, event)
+
+	return nil
+}
+
+// Map records mappings.
+func (c *Client) Map(mappings ...*amplitude.UserMapping) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if err := c.check(); err != nil {
+		return err
+	}
+
+	c.mappings = append(c.mappings, mappings...)
+
+	return nil
+}
+
+// Attribute records attribution.
+func (c *Client) Attribute(attribution *amplitude.Attribution) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if err := c.check(); err != nil {
+		return err
+	}
+
+	c.attributions = append(c.attributions, attribution)
+
+	return nil
+}
+
+// Close closes the client, it returns amplitude.ErrClosed when called more than once.
+func (c *Client) Close() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.closed {
+		return amplitude.ErrClosed
+	}
+
+	c.closed = true
+
+	return nil
+}
+
+// Closed returns whether Close was called.
+func (c *Client) Closed() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.closed
+}
+
+// Events returns the recorded events, in order.
+func (c *Client) Events() []*amplitude.Event {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return append([]*amplitude.Event(nil), c.events...)
+}
+
+// Find returns the recorded events matching match.
+func (c *Client) Find(match func(event *amplitude.Event) bool) []*amplitude.Event {
+	var events []*amplitude.Event
+
+	for _, event := range c.Events() {
+		if match(event) {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// EventsOfType returns the recorded events of eventType.
+func (c *Client) EventsOfType(eventType string) []*amplitude.Event {
+	return c.Find(func(event *amplitude.Event) bool {
+		return event.EventType == eventType
+	})
+}
+
+// EventsOfUser returns the recorded events of userID.
+func (c *Client) EventsOfUser(userID string) []*amplitude.Event {
+	return c.Find(func(event *amplitude.Event) bool {
+		return event.UserID == userID
+	})
+}
+
+// LastEvent returns the last recorded event, or nil.
+func (c *Client) LastEvent() *amplitude.Event {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if len(c.events) == 0 {
+		return nil
+	}
+
+	return c.events[len(c.events)-1]
+}
+
+// Mappings returns the recorded user mappings.
+func (c *Client) Mappings() []*amplitude.UserMapping {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return append([]*amplitude.UserMapping(nil), c.mappings...)
+}
+
+// Attributions returns the recorded attributions.
+func (c *Client) Attributions() []*amplitude.Attribution {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return append([]*amplitude.Attribution(nil), c.attributions...)
+}
+
+// Reset forgets the recorded data, the error and reopens the client.
+func (c *Client) Reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.err = nil
+	c.closed = false
+	c.events = nil
+	c.mappings = nil
+	c.attributions = nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitudetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	c := NewClient()
+
+	assert.NoError(t, c.Enqueue(&amplitude.Event{EventType: "user.created", UserID: "user-1"}))
+
+	// The timestamp is set like the amplitude client does.
+	assert.NotZero(t, c.LastEvent().Timestamp)
+
+	ctx := amplitude.ContextWithEventProperties(context.Background(), map[string]interface{}{
+		"request_id": "req-1",
+		"source":     "ctx",
+	})
+
+	event := &amplitude.Event{
+		EventType: "Song Played",
+		UserID:    "user-2",
+		Timestamp: 1234,
+		EventProperties: map[string]interface{}{
+			"source": "event",
+		},
+	}
+
+	assert.NoError(t, c.EnqueueContext(ctx, event))
+
+	assert.Len(t, c.Events(), 2)
+	assert.Len(t, c.EventsOfType("user.created"), 1)
+	assert.Len(t, c.EventsOfUser("user-2"), 1)
+
+	last := c.LastEvent()
+	assert.Equal(t, map[string]interface{}{"request_id": "req-1", "source": "event"}, last.EventProperties)
+	assert.Equal(t, int64(1234), last.Timestamp)
+
+	// The event of the caller is left untouched.
+	assert.Equal(t, map[string]interface{}{"source": "event"}, event.EventProperties)
+
+	assert.NoError(t, c.Map(&amplitude.UserMapping{UserID: "user-1", GlobalUserID: "global-1"}))
+	assert.NoError(t, c.Attribute(&amplitude.Attribution{EventType: "Install"}))
+
+	assert.Len(t, c.Mappings(), 1)
+	assert.Len(t, c.Attributions(), 1)
+}
+
+func TestClientError(t *testing.T) {
+	c := NewClient()
+
+	errFailed := errors.New("failed")
+
+	c.SetError(errFailed)
+
+	assert.ErrorIs(t, c.Enqueue(&amplitude.Event{EventType: "user.created"}), errFailed)
+	assert.ErrorIs(t, c.Map(&amplitude.UserMapping{}), errFailed)
+	assert.ErrorIs(t, c.Attribute(&amplitude.Attribution{}), errFailed)
+	assert.Empty(t, c.Events())
+	assert.Nil(t, c.LastEvent())
+
+	c.SetError(nil)
+
+	assert.NoError(t, c.Enqueue(&amplitude.Event{EventType: "user.created"}))
+}
+
+func TestClientClose(t *testing.T) {
+	c := NewClient()
+
+	assert.False(t, c.Closed())
+	assert.NoError(t, c.Close())
+	assert.True(t, c.Closed())
+	assert.ErrorIs(t, c.Close(), amplitude.ErrClosed)
+	assert.ErrorIs(t, c.Enqueue(&amplitude.Event{EventType: "user.created"}), amplitude.ErrClosed)
+
+	c.Reset()
+
+	assert.False(t, c.Closed())
+	assert.NoError(t, c.Enqueue(&amplitude.Event{EventType: "user.created"}))
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitudetest
+
+import (
+	"net/http"
+)
+
+// Response scripted with Server.Respond.
+type Response struct {
+	StatusCode int
+
+	// Body is encoded in JSON, except strings which are written as is.
+	Body interface{}
+}
+
+// BadRequest returns the response of the HTTP API to a request missing a field.
+func BadRequest(missingField string) *Response {
+	return &Response{
+		StatusCode: http.StatusBadRequest,
+		Body: map[string]interface{}{
+			"code":          http.StatusBadRequest,
+			"error":         "Request missing required field",
+			"missing_field": missingField,
+		},
+	}
+}
+
+// InvalidEvents returns the response of the HTTP API to events with invalid fields,
+// the indexes of the invalid events by field name.
+func InvalidEvents(fields map[string][]int) *Response {
+	return &Response{
+		StatusCode: http.StatusBadRequest,
+		Body: map[string]interface{}{
+			"code":                       http.StatusBadRequest,
+			"error":                      "Invalid field values on some events",
+			"events_with_invalid_fields": fields,
+		},
+	}
+}
+
+// PayloadTooLarge returns the response of the HTTP API to a payload over its size limit.
+func PayloadTooLarge() *Response {
+	return &Response{
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Body: map[string]interface{}{
+			"code":  http.StatusRequestEntityTooLarge,
+			"error": "Payload too large",
+		},
+	}
+}
+
+// TooManyRequests returns the response of the HTTP API throttling the users, by user ID.
+func TooManyRequests(throttledUsers map[string]int) *Response {
+	return &Response{
+		StatusCode: http.StatusTooManyRequests,
+		Body: map[string]interface{}{
+			"code":              http.StatusTooManyRequests,
+			"error":             "Too many requests for some devices and users",
+			"eps_threshold":     30,
+			"throttled_devices": map[string]int{},
+			"throttled_users":   throttledUsers,
+			"throttled_events":  []int{},
+		},
+	}
+}
+
+// ServerError returns a server error response, e.g. 500, 502, 503 or 504.
+func ServerError(statusCode int) *Response {
+	return &Response{
+		StatusCode: statusCode,
+		Body:       http.StatusText(statusCode),
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Endpoints of the attribution API.
+// see: https://amplitude.com/docs/apis/analytics/attribution
+const (
+	StandardAttributionEndpoint    = "https://api2.amplitude.com/attribution"
+	EUResidencyAttributionEndpoint = "https://api.eu.amplitude.com/attribution"
+)
+
+// ErrInvalidAttribution is returned by Attribute for attributions without event type, platform or advertising ID.
+var ErrInvalidAttribution = errors.New("invalid attribution")
+
+// Attribution event of an ad network, matched to users by their advertising ID.
+type Attribution struct {
+	EventType      string                 `json:"event_type"`
+	Platform       string                 `json:"platform"`
+	IDFA           string                 `json:"idfa,omitempty"`
+	IDFV           string                 `json:"idfv,omitempty"`
+	ADID           string                 `json:"adid,omitempty"`
+	AndroidID      string                 `json:"android_id,omitempty"`
+	UserProperties map[string]interface{} `json:"user_properties,omitempty"`
+	Timestamp      int64                  `json:"time,omitempty"`
+}
+
+func (a *Attribution) validate() error {
+	if a.EventType == "" {
+		return fmt.Errorf("%w: event_type is required", ErrInvalidAttribution)
+	}
+
+	if a.Platform == "" {
+		return fmt.Errorf("%w: platform is required", ErrInvalidAttribution)
+	}
+
+	if a.IDFA == "" && a.IDFV == "" && a.ADID == "" && a.AndroidID == "" {
+		return fmt.Errorf("%w: one of idfa, idfv, adid or android_id is required", ErrInvalidAttribution)
+	}
+
+	return nil
+}
+
+// attributionURLFor returns the attribution endpoint of the region of the HTTP API endpoint.
+func attributionURLFor(endpoint string) string {
+	if endpoint == EUResidencyEndpoint {
+		return EUResidencyAttributionEndpoint
+	}
+
+	return StandardAttributionEndpoint
+}
+
+// Attribute enqueues an attribution event, the API accepts one event per request.
+func (c *client) Attribute(attribution *Attribution) (err error) {
+	if err := attribution.validate(); err != nil {
+		return err
+	}
+
+	defer func() {
+		// See EnqueueContext.
+		if recover() != nil {
+			err = ErrClosed
+		}
+	}()
+
+	c.attributions <- attribution
+
+	return nil
+}
+
+func (c *client) sendAttribution(attribution *Attribution) {
+	b, err := json.Marshal(attribution)
+	if err != nil {
+		log.Error().Err(err).Str("event_type", attribution.EventType).Msg("json marshal attribution failed")
+
+		return
+	}
+
+	form := url.Values{
+		"api_key": {c.key},
+		"event":   {string(b)},
+	}
+
+	c.deliver(&Payload{
+		Kind:        PayloadAttribution,
+		URL:         c.attributionURL,
+		ContentType: "application/x-www-form-urlencoded",
+		Body:        []byte(form.Encode()),
+		Size:        1,
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributionURLFor(t *testing.T) {
+	assert.Equal(t, StandardAttributionEndpoint, attributionURLFor(StandardEndpoint))
+	assert.Equal(t, EUResidencyAttributionEndpoint, attributionURLFor(EUResidencyEndpoint))
+}
+
+func TestClientAttribute(t *testing.T) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	attempts := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "foo", r.PostForm.Get("api_key"))
+		assert.JSONEq(t, `{"event_type":"[Adjust] Install","platform":"ios","idfa":"AEBE52E7-03EE-455A-B3C4-E57283966239","user_properties":{"[Adjust] media source":"facebook"}}`, r.PostForm.Get("event"))
+
+		// The first attempt fails and the attribution is retried.
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	c := start(
+		"foo",
+		WithAttributionURL(ts.URL),
+		WithInterval(time.Millisecond*100),
+		WithCallback(func(payload *Payload, err error) {
+			defer wg.Done()
+
+			assert.NoError(t, err)
+			assert.Equal(t, PayloadAttribution, payload.Kind)
+			assert.Equal(t, 2, payload.Attempts)
+		}),
+	)
+
+	assert.NoError(t, c.Attribute(&Attribution{
+		EventType: "[Adjust] Install",
+		Platform:  "ios",
+		IDFA:      "AEBE52E7-03EE-455A-B3C4-E57283966239",
+		UserProperties: map[string]interface{}{
+			"[Adjust] media source": "facebook",
+		},
+	}))
+
+	wg.Wait()
+
+	assert.NoError(t, c.Close())
+
+	assert.Equal(t, 2, attempts)
+	assert.ErrorIs(t, c.Attribute(&Attribution{EventType: "Install", Platform: "ios", IDFA: "foo"}), ErrClosed)
+}
+
+func TestClientAttributeInvalid(t *testing.T) {
+	c := start("foo")
+	defer c.Close()
+
+	assert.ErrorIs(t, c.Attribute(&Attribution{Platform: "ios", IDFA: "foo"}), ErrInvalidAttribution)
+	assert.ErrorIs(t, c.Attribute(&Attribution{EventType: "Install", IDFA: "foo"}), ErrInvalidAttribution)
+	assert.ErrorIs(t, c.Attribute(&Attribution{EventType: "Install", Platform: "ios"}), ErrInvalidAttribution)
+}
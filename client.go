@@ -9,10 +9,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/euskadi31/go-amplitude/clock"
 	"github.com/rs/zerolog/log"
 )
 
@@ -29,55 +34,146 @@ type Client interface {
 	Close() error
 }
 
+// ContextClient is a Client enqueueing the events with the event properties
+// carried by a context, see EnqueueContext. The clients returned by New
+// implement it.
+type ContextClient interface {
+	Client
+	EnqueueContext(ctx context.Context, event *Event) error
+}
+
+// UserMapper is implemented by the clients mapping users across projects,
+// such as the clients returned by New.
+type UserMapper interface {
+	Map(mappings ...*UserMapping) error
+}
+
+// Attributor is implemented by the clients forwarding attribution events,
+// such as the clients returned by New.
+type Attributor interface {
+	Attribute(attribution *Attribution) error
+}
+
+var (
+	_ ContextClient = (*client)(nil)
+	_ UserMapper    = (*client)(nil)
+	_ Attributor    = (*client)(nil)
+)
+
+// EnqueueContext enqueues event with client and the event properties carried
+// by ctx, see ContextWithEventProperties. The properties are added to event
+// when client is not a ContextClient.
+func EnqueueContext(ctx context.Context, client Client, event *Event) error {
+	if c, ok := client.(ContextClient); ok {
+		return c.EnqueueContext(ctx, event)
+	}
+
+	addContextProperties(ctx, event)
+
+	return client.Enqueue(event)
+}
+
 type client struct {
-	endpoint      string
-	key           string
-	timeout       time.Duration
-	interval      time.Duration
-	batchSize     int
-	bufferSize    int
-	maxRetry      int
-	retryInterval time.Duration
-	retrySize     int
-	httpClient    *http.Client
-	msgs          chan *Event
-	events        []*Event
-	retries       chan *Payload
-	quitCh        chan struct{}
-	shutdownCh    chan struct{}
-	flushCh       chan struct{}
-	mtx           sync.Mutex
+	endpoint       string
+	userMapURL     string
+	attributionURL string
+	key            string
+	timeout        time.Duration
+	interval       time.Duration
+	batchSize      int
+	bufferSize     int
+	maxRetry       int
+	retryInterval  time.Duration
+	retrySize      int
+	httpClient     *http.Client
+	clock          clock.Clock
+	plugins        []Plugin
+	callback       Callback
+	dryRun         *payloadWriter
+	debug          *payloadWriter
+	msgs           chan *Event
+	events         []*Event
+	mappingMsgs    chan *UserMapping
+	mappings       []*UserMapping
+	attributions   chan *Attribution
+	retries        []*retry
+	retryTimer     clock.Timer
+	retryAt        time.Time
+	quitCh         chan struct{}
+	shutdownCh     chan struct{}
+	flushCh        chan struct{}
+	mtx            sync.Mutex
 }
 
-// New Amplitude client.
+// defaultBufferSize is the default buffer size and the capacity of the queue of Enqueue.
+const defaultBufferSize = 2000
+
+// New Amplitude client, it also implements ContextClient, UserMapper and Attributor.
 func New(key string, opts ...Option) Client {
+	return start(key, opts...)
+}
+
+// start returns a client configured by opts running its loop.
+func start(key string, opts ...Option) *client {
+	c := newClient(key, opts...)
+
+	c.quitCh = make(chan struct{}, 1)
+	c.shutdownCh = make(chan struct{}, 1)
+	c.flushCh = make(chan struct{}, 1)
+	c.msgs = make(chan *Event, defaultBufferSize)
+	c.mappingMsgs = make(chan *UserMapping, c.bufferSize)
+	c.attributions = make(chan *Attribution, c.bufferSize)
+
+	go c.loop()
+
+	return c
+}
+
+// newClient returns a client configured by opts, without its channels and loop.
+func newClient(key string, opts ...Option) *client {
 	c := &client{
 		endpoint:      StandardEndpoint,
 		key:           key,
 		timeout:       time.Second * 1,
 		interval:      time.Second * 10,
 		batchSize:     1000,
-		bufferSize:    2000,
+		bufferSize:    defaultBufferSize,
 		maxRetry:      3,
 		retryInterval: time.Second * 1,
 		retrySize:     1000,
-		quitCh:        make(chan struct{}, 1),
-		shutdownCh:    make(chan struct{}, 1),
-		flushCh:       make(chan struct{}, 1),
+		clock:         clock.Real(),
 	}
 
 	c.httpClient = &http.Client{
 		Timeout: c.timeout,
 	}
-	c.msgs = make(chan *Event, c.bufferSize)
 	c.events = []*Event{} /*make(, 0, c.bufferSize)*/
-	c.retries = make(chan *Payload, c.retrySize)
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
-	go c.loop()
+	for _, plugin := range c.plugins {
+		if p, ok := plugin.(clockSetter); ok {
+			p.setClock(c.clock)
+		}
+	}
+
+	if c.dryRun == nil {
+		c.dryRun = payloadWriterFromEnv(DryRunEnv)
+	}
+
+	if c.debug == nil {
+		c.debug = payloadWriterFromEnv(DebugEnv)
+	}
+
+	if c.userMapURL == "" {
+		c.userMapURL = userMapURLFor(c.endpoint)
+	}
+
+	if c.attributionURL == "" {
+		c.attributionURL = attributionURLFor(c.endpoint)
+	}
 
 	return c
 }
@@ -96,27 +192,23 @@ func (c *client) addEvent(event *Event) {
 func (c *client) loop() {
 	defer close(c.shutdownCh)
 
-	tick := time.NewTicker(c.interval)
+	tick := c.clock.NewTicker(c.interval)
 	defer tick.Stop()
 
 	for {
 		select {
 		case <-c.flushCh:
 			c.flush()
-		case payload := <-c.retries:
-			if err := c.sendBatch(payload); err != nil {
-				if payload.Attempts > c.maxRetry {
-					log.Warn().Msgf("%d messages dropped because they failed to be sent after %d attempts", payload.Size, c.maxRetry)
-
-					continue
-				}
-
-				c.retries <- payload
-			}
+		case <-c.retryC():
+			c.retryDue()
 		case event := <-c.msgs:
 			c.addEvent(event)
+		case mapping := <-c.mappingMsgs:
+			c.addMapping(mapping)
+		case attribution := <-c.attributions:
+			c.sendAttribution(attribution)
 
-		case <-tick.C:
+		case <-tick.C():
 			c.flush()
 
 		case <-c.quitCh:
@@ -130,16 +222,22 @@ func (c *client) loop() {
 				c.addEvent(event)
 			}
 
-			c.flush()
+			close(c.mappingMsgs)
+
+			for mapping := range c.mappingMsgs {
+				c.addMapping(mapping)
+			}
 
-			close(c.retries)
+			close(c.attributions)
 
-			for payload := range c.retries {
-				if err := c.sendBatch(payload); err != nil {
-					log.Error().Msg("Amplitude send batch failed, events lost !")
-				}
+			for attribution := range c.attributions {
+				c.sendAttribution(attribution)
 			}
 
+			c.flush()
+
+			c.drainRetries()
+
 			log.Debug().Msg("exit")
 
 			return
@@ -163,14 +261,134 @@ func (c *client) Close() (err error) {
 	return
 }
 
+// maxErrorSize is the maximum number of bytes of an error response read by processErrorResponse.
+const maxErrorSize = 4096
+
+// processErrorResponse returns the *ErrorResponse of JSON error responses, or an *APIError.
 func (c *client) processErrorResponse(resp *http.Response) error {
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorSize))
+	if err != nil {
+		return fmt.Errorf("read response body failed: %w", err)
+	}
+
 	var errorResponse *ErrorResponse
 
-	if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
-		return fmt.Errorf("json decode failed: %w", err)
+	if err := json.Unmarshal(b, &errorResponse); err == nil && errorResponse != nil && errorResponse.Code != 0 {
+		return errorResponse
+	}
+
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(b)),
+	}
+}
+
+// retry is a payload waiting for its next attempt.
+type retry struct {
+	payload *Payload
+	at      time.Time
+}
+
+// deliver sends payload, it is scheduled for retry on failure and dropped after maxRetry retries.
+func (c *client) deliver(payload *Payload) {
+	err := c.sendBatch(payload)
+	if err == nil {
+		c.report(payload, nil)
+
+		return
+	}
+
+	if payload.Attempts > c.maxRetry {
+		log.Warn().Msgf("%d messages dropped because they failed to be sent after %d attempts", payload.Size, c.maxRetry)
+
+		c.report(payload, err)
+
+		return
+	}
+
+	c.scheduleRetry(payload, err)
+}
+
+// scheduleRetry queues payload for a retry after retryInterval times its number of attempts.
+func (c *client) scheduleRetry(payload *Payload, err error) {
+	if len(c.retries) >= c.retrySize {
+		log.Warn().Msgf("%d messages dropped because the retry queue is full", payload.Size)
+
+		c.report(payload, err)
+
+		return
+	}
+
+	at := c.clock.Now().Add(c.retryInterval * time.Duration(payload.Attempts))
+
+	i := sort.Search(len(c.retries), func(i int) bool {
+		return c.retries[i].at.After(at)
+	})
+
+	c.retries = slices.Insert(c.retries, i, &retry{
+		payload: payload,
+		at:      at,
+	})
+
+	// The timer is armed again by retryC for the earlier retry.
+	if c.retryTimer != nil && at.Before(c.retryAt) {
+		c.retryTimer.Stop()
+		c.retryTimer = nil
 	}
+}
+
+// retryC returns the channel of the timer of the next retry, nil when no retry is pending.
+func (c *client) retryC() <-chan time.Time {
+	if len(c.retries) == 0 {
+		return nil
+	}
+
+	if c.retryTimer == nil {
+		c.retryAt = c.retries[0].at
+		c.retryTimer = c.clock.NewTimer(c.retryAt.Sub(c.clock.Now()))
+	}
+
+	return c.retryTimer.C()
+}
 
-	return errorResponse
+// drainRetries attempts the pending retries once more, without waiting for their backoff.
+func (c *client) drainRetries() {
+	if c.retryTimer != nil {
+		c.retryTimer.Stop()
+		c.retryTimer = nil
+	}
+
+	for _, r := range c.retries {
+		err := c.sendBatch(r.payload)
+		if err != nil {
+			log.Error().Msg("Amplitude send batch failed, events lost !")
+		}
+
+		c.report(r.payload, err)
+	}
+
+	c.retries = nil
+}
+
+// retryDue delivers the retries whose backoff is over.
+func (c *client) retryDue() {
+	c.retryTimer = nil
+
+	now := c.clock.Now()
+
+	for len(c.retries) > 0 && !c.retries[0].at.After(now) {
+		r := c.retries[0]
+		c.retries = c.retries[1:]
+
+		c.deliver(r.payload)
+	}
+}
+
+// report calls the callback with the outcome of the delivery of payload.
+func (c *client) report(payload *Payload, err error) {
+	if c.callback != nil {
+		c.callback(payload, err)
+	}
 }
 
 func (c *client) sendBatch(payload *Payload) error {
@@ -178,12 +396,37 @@ func (c *client) sendBatch(payload *Payload) error {
 
 	payload.Attempts++
 
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(payload.Body))
+	// A dry run never fails, the payload was not meant to be sent.
+	if c.dryRun != nil {
+		if err := c.dryRun.write(payload); err != nil {
+			log.Error().Err(err).Msg("Amplitude dry-run failed")
+		}
+
+		return nil
+	}
+
+	if c.debug != nil {
+		if err := c.debug.write(payload); err != nil {
+			log.Error().Err(err).Msg("Amplitude debug failed")
+		}
+	}
+
+	endpoint := payload.URL
+	if endpoint == "" {
+		endpoint = c.endpoint
+	}
+
+	contentType := payload.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload.Body))
 	if err != nil {
 		return fmt.Errorf("http new request failed: %w", err)
 	}
 
-	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Content-Type", contentType)
 	r.Header.Set("Accept", "application/json")
 	r.Header.Set("User-Agent", userAgent)
 
@@ -193,7 +436,10 @@ func (c *client) sendBatch(payload *Payload) error {
 	if err != nil {
 		log.Error().Err(err).Msg("")
 
-		return fmt.Errorf("http client send request failed: %w", err)
+		return &DeliveryError{
+			Kind: payload.Kind,
+			Err:  fmt.Errorf("http client send request failed: %w", err),
+		}
 	}
 
 	defer func() {
@@ -207,7 +453,10 @@ func (c *client) sendBatch(payload *Payload) error {
 
 		log.Error().Err(err).Msgf("Amplitude send batch failed: status code %d", resp.StatusCode)
 
-		return ErrBatchFailed
+		return &DeliveryError{
+			Kind: payload.Kind,
+			Err:  err,
+		}
 	}
 
 	log.Debug().Msg("Amplitude sent batch !")
@@ -236,6 +485,10 @@ func (c *client) getBatchEvents() []*Event {
 }
 
 func (c *client) flush() error {
+	if err := c.flushMappings(); err != nil {
+		return err
+	}
+
 	events := c.getBatchEvents()
 
 	if len(events) == 0 {
@@ -252,21 +505,30 @@ func (c *client) flush() error {
 		return fmt.Errorf("json marshal events failed: %w", err)
 	}
 
-	payload := &Payload{
+	c.deliver(&Payload{
+		Kind: PayloadEvents,
 		Body: b,
 		Size: len(events),
-	}
-
-	if err := c.sendBatch(payload); err != nil {
-		c.retries <- payload
-	}
+	})
 
 	return nil
 }
 
-func (c *client) Enqueue(event *Event) (err error) {
+func (c *client) Enqueue(event *Event) error {
+	return c.EnqueueContext(context.Background(), event)
+}
+
+// EnqueueContext enqueues event with the event properties carried by ctx, see ContextWithEventProperties.
+func (c *client) EnqueueContext(ctx context.Context, event *Event) (err error) {
 	if event.Timestamp == 0 {
-		event.Timestamp = time.Now().UTC().Unix()
+		event.Timestamp = c.clock.Now().UTC().Unix()
+	}
+
+	addContextProperties(ctx, event)
+
+	events, err := processEvent(ctx, c.plugins, event)
+	if err != nil {
+		return err
 	}
 
 	defer func() {
@@ -279,11 +541,17 @@ func (c *client) Enqueue(event *Event) (err error) {
 		}
 	}()
 
-	if len(c.msgs) == (cap(c.msgs) - 1) {
-		c.flushCh <- struct{}{}
-	}
+	for _, e := range events {
+		if len(c.msgs) == (cap(c.msgs) - 1) {
+			c.flushCh <- struct{}{}
+		}
 
-	c.msgs <- event
+		dest := destinationEvent(c.plugins, e)
+
+		c.msgs <- e
+
+		deliverEvent(ctx, c.plugins, dest)
+	}
 
 	return
 }
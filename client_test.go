@@ -5,6 +5,7 @@
 package amplitude
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -13,9 +14,18 @@ import (
 	"testing"
 	"time"
 
+	"github.com/euskadi31/go-amplitude/clock"
 	"github.com/stretchr/testify/assert"
 )
 
+// bufferedEvents returns the number of events waiting for the next flush.
+func bufferedEvents(c *client) int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return len(c.events)
+}
+
 func TestClient(t *testing.T) {
 	var wg sync.WaitGroup
 
@@ -70,6 +80,47 @@ func TestClient(t *testing.T) {
 	wg.Wait()
 }
 
+// enqueueClient is a Client without EnqueueContext.
+type enqueueClient struct {
+	events []*Event
+}
+
+func (c *enqueueClient) Enqueue(event *Event) error {
+	c.events = append(c.events, event)
+
+	return nil
+}
+
+func (c *enqueueClient) Close() error {
+	return nil
+}
+
+func TestEnqueueContext(t *testing.T) {
+	ctx := ContextWithEventProperties(context.Background(), map[string]interface{}{"request_id": "1"})
+
+	c := &enqueueClient{}
+
+	assert.NoError(t, EnqueueContext(ctx, c, &Event{EventType: "user.created"}))
+
+	assert.Equal(t, []*Event{{
+		EventType:       "user.created",
+		EventProperties: map[string]interface{}{"request_id": "1"},
+	}}, c.events)
+
+	fake := clock.NewFake(time.Unix(1700000000, 0))
+
+	cc := start("foo", WithDryRun(io.Discard, DryRunNDJSON), WithClock(fake))
+
+	event := &Event{EventType: "user.created"}
+
+	assert.NoError(t, EnqueueContext(ctx, cc, event))
+	assert.NoError(t, cc.Close())
+
+	// The ContextClient sets the timestamp.
+	assert.Equal(t, int64(1700000000), event.Timestamp)
+	assert.Equal(t, map[string]interface{}{"request_id": "1"}, event.EventProperties)
+}
+
 func TestClientWithRetry(t *testing.T) {
 	var wg sync.WaitGroup
 
@@ -104,6 +155,8 @@ func TestClientWithRetry(t *testing.T) {
 	}))
 	defer ts.Close()
 
+	fake := clock.NewFake(time.Unix(1643367217, 0))
+
 	c := New(
 		"foo",
 		WithURL(ts.URL),
@@ -112,7 +165,8 @@ func TestClientWithRetry(t *testing.T) {
 		WithBatchSize(2),
 		WithBufferSize(2),
 		WithMaxRetry(2),
-	)
+		WithClock(fake),
+	).(*client)
 	defer c.Close()
 
 	err := c.Enqueue(&Event{
@@ -129,6 +183,21 @@ func TestClientWithRetry(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
+	assert.Eventually(t, func() bool {
+		return bufferedEvents(c) == 1
+	}, time.Second, time.Millisecond)
+
+	// The first attempt fails on flush.
+	fake.Advance(time.Millisecond * 100)
+
+	// The retry is sent once the retry interval is over.
+	fake.BlockUntil(2)
+	fake.Advance(time.Millisecond * 999)
+
+	assert.Equal(t, 2, fake.Waiters())
+
+	fake.Advance(time.Millisecond)
+
 	wg.Wait()
 }
 
@@ -148,6 +217,8 @@ func TestClientDroppedMessage(t *testing.T) {
 	}))
 	defer ts.Close()
 
+	fake := clock.NewFake(time.Unix(1643367217, 0))
+
 	c := New(
 		"foo",
 		WithURL(ts.URL),
@@ -157,7 +228,8 @@ func TestClientDroppedMessage(t *testing.T) {
 		WithBufferSize(2),
 		WithMaxRetry(2),
 		WithRetryInterval(time.Second*1),
-	)
+		WithClock(fake),
+	).(*client)
 	defer c.Close()
 
 	err := c.Enqueue(&Event{
@@ -174,6 +246,19 @@ func TestClientDroppedMessage(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
+	assert.Eventually(t, func() bool {
+		return bufferedEvents(c) == 1
+	}, time.Second, time.Millisecond)
+
+	fake.Advance(time.Millisecond * 100)
+
+	// The backoff grows with the number of attempts.
+	fake.BlockUntil(2)
+	fake.Advance(time.Second)
+
+	fake.BlockUntil(2)
+	fake.Advance(time.Second * 2)
+
 	wg.Wait()
 
 	assert.Equal(t, 3, retry)
@@ -339,6 +424,119 @@ func TestClientClose(t *testing.T) {
 	wg.Wait()
 }
 
+func TestClientWithTrackingPlan(t *testing.T) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer wg.Done()
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		defer r.Body.Close()
+
+		assert.Equal(t, `{"api_key":"foo","events":[{"user_id":"f892be22-8f8e-445d-83b0-af199b9a5c72","event_type":"user.created","time":1643367217,"event_properties":{"from":"mobile"},"plan":{"branch":"main","source":"backend","version":"3"}}]}`, string(b))
+	}))
+	defer ts.Close()
+
+	plan, err := LoadTrackingPlanFile("testdata/tracking_plan.json")
+	assert.NoError(t, err)
+
+	c := New(
+		"foo",
+		WithURL(ts.URL),
+		WithInterval(time.Millisecond*100),
+		WithTrackingPlan(plan, ValidationReject),
+	)
+	defer c.Close()
+
+	err = c.Enqueue(&Event{
+		UserID:    "f892be22-8f8e-445d-83b0-af199b9a5c72",
+		Timestamp: 1643367217,
+		EventType: "user.unknown",
+	})
+	assert.ErrorIs(t, err, ErrInvalidEvent)
+
+	err = c.Enqueue(&Event{
+		UserID:    "f892be22-8f8e-445d-83b0-af199b9a5c72",
+		Timestamp: 1643367217,
+		EventType: "user.created",
+		EventProperties: map[string]interface{}{
+			"from": "mobile",
+		},
+	})
+	assert.NoError(t, err)
+
+	wg.Wait()
+}
+
+func TestClientDefaultTimestamp(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	c := New("foo", WithDryRun(io.Discard, DryRunNDJSON), WithClock(fake))
+	defer c.Close()
+
+	event := &Event{
+		UserID:    "f892be22-8f8e-445d-83b0-af199b9a5c72",
+		EventType: "user.created",
+	}
+
+	assert.NoError(t, c.Enqueue(event))
+	assert.Equal(t, fake.Now().Unix(), event.Timestamp)
+}
+
+func TestClientRetryQueueFull(t *testing.T) {
+	var errs []error
+
+	fake := clock.NewFake(time.Unix(1643367217, 0))
+
+	c := &client{
+		retryInterval: time.Second,
+		retrySize:     1,
+		clock:         fake,
+		callback: func(payload *Payload, err error) {
+			errs = append(errs, err)
+		},
+	}
+
+	c.scheduleRetry(&Payload{Attempts: 2}, nil)
+	assert.Len(t, errs, 0)
+
+	c.scheduleRetry(&Payload{Attempts: 1}, ErrBatchFailed)
+	assert.Equal(t, []error{ErrBatchFailed}, errs)
+
+	assert.Len(t, c.retries, 1)
+	assert.Equal(t, fake.Now().Add(time.Second*2), c.retries[0].at)
+}
+
+func TestClientRetryOrder(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1643367217, 0))
+
+	c := &client{
+		retryInterval: time.Second,
+		retrySize:     10,
+		clock:         fake,
+	}
+
+	first := &Payload{Attempts: 2}
+	second := &Payload{Attempts: 1}
+
+	c.scheduleRetry(first, nil)
+
+	assert.NotNil(t, c.retryC())
+	assert.Equal(t, fake.Now().Add(time.Second*2), c.retryAt)
+
+	// An earlier retry rearms the timer.
+	c.scheduleRetry(second, nil)
+
+	assert.Nil(t, c.retryTimer)
+	assert.NotNil(t, c.retryC())
+	assert.Equal(t, fake.Now().Add(time.Second), c.retryAt)
+	assert.Equal(t, []*Payload{second, first}, []*Payload{c.retries[0].payload, c.retries[1].payload})
+}
+
 func TestClientGetBatchEvents(t *testing.T) {
 
 	c := &client{
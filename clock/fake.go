@@ -0,0 +1,186 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+var _ Clock = (*Fake)(nil)
+
+// Fake is a clock which only moves when told to, see Advance and Set.
+// Tickers and timers fire when the time reaches their deadline.
+type Fake struct {
+	mtx     sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*waiter
+}
+
+// NewFake returns a fake clock set at now.
+func NewFake(now time.Time) *Fake {
+	f := &Fake{
+		now: now,
+	}
+
+	f.cond = sync.NewCond(&f.mtx)
+
+	return f
+}
+
+// Now returns the time of the clock.
+func (f *Fake) Now() time.Time {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	return f.now
+}
+
+// NewTicker returns a ticker firing every d of fake time.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+
+	return &fakeTicker{waiter: f.add(d, d)}
+}
+
+// NewTimer returns a timer firing after d of fake time.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	w := f.add(d, 0)
+
+	// An expired timer fires right away, as time.NewTimer does.
+	if d <= 0 {
+		f.Advance(0)
+	}
+
+	return w
+}
+
+// Advance moves the clock forward by d and fires the tickers and timers reaching their deadline.
+func (f *Fake) Advance(d time.Duration) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.set(f.now.Add(d))
+}
+
+// Set moves the clock to now and fires the tickers and timers reaching their deadline.
+func (f *Fake) Set(now time.Time) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.set(now)
+}
+
+// BlockUntil waits until n tickers and timers are waiting on the clock.
+// It lets a test advance the clock once the code under test is ready.
+func (f *Fake) BlockUntil(n int) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+// Waiters returns the number of tickers and timers waiting on the clock.
+func (f *Fake) Waiters() int {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	return len(f.waiters)
+}
+
+func (f *Fake) add(d time.Duration, period time.Duration) *waiter {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	w := &waiter{
+		clock:    f,
+		deadline: f.now.Add(d),
+		period:   period,
+		ch:       make(chan time.Time, 1),
+	}
+
+	f.waiters = append(f.waiters, w)
+	f.cond.Broadcast()
+
+	return w
+}
+
+// set must be called with the lock held.
+func (f *Fake) set(now time.Time) {
+	f.now = now
+
+	waiters := f.waiters[:0]
+
+	for _, w := range f.waiters {
+		if w.deadline.After(now) {
+			waiters = append(waiters, w)
+
+			continue
+		}
+
+		// Like time.Ticker, ticks are dropped when the receiver is late.
+		select {
+		case w.ch <- now:
+		default:
+		}
+
+		if w.period == 0 {
+			continue
+		}
+
+		for !w.deadline.After(now) {
+			w.deadline = w.deadline.Add(w.period)
+		}
+
+		waiters = append(waiters, w)
+	}
+
+	f.waiters = waiters
+}
+
+func (f *Fake) remove(w *waiter) bool {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	for i, v := range f.waiters {
+		if v == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// waiter is a fake ticker, or a fake timer when period is zero.
+type waiter struct {
+	clock    *Fake
+	deadline time.Time
+	period   time.Duration
+	ch       chan time.Time
+}
+
+func (w *waiter) C() <-chan time.Time {
+	return w.ch
+}
+
+// Stop reports whether the timer was stopped before firing.
+func (w *waiter) Stop() bool {
+	return w.clock.remove(w)
+}
+
+type fakeTicker struct {
+	*waiter
+}
+
+func (t *fakeTicker) Stop() {
+	t.waiter.Stop()
+}
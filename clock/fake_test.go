@@ -0,0 +1,139 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var epoch = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func received(ch <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-ch:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFakeNow(t *testing.T) {
+	f := NewFake(epoch)
+
+	assert.Equal(t, epoch, f.Now())
+
+	f.Advance(time.Minute)
+
+	assert.Equal(t, epoch.Add(time.Minute), f.Now())
+
+	f.Set(epoch)
+
+	assert.Equal(t, epoch, f.Now())
+}
+
+func TestFakeTicker(t *testing.T) {
+	f := NewFake(epoch)
+
+	ticker := f.NewTicker(time.Second)
+
+	assert.Equal(t, 1, f.Waiters())
+
+	f.Advance(time.Millisecond * 999)
+
+	_, ok := received(ticker.C())
+	assert.False(t, ok)
+
+	f.Advance(time.Millisecond)
+
+	tick, ok := received(ticker.C())
+	assert.True(t, ok)
+	assert.Equal(t, epoch.Add(time.Second), tick)
+
+	// Late ticks are dropped.
+	f.Advance(time.Second * 5)
+
+	_, ok = received(ticker.C())
+	assert.True(t, ok)
+
+	_, ok = received(ticker.C())
+	assert.False(t, ok)
+
+	f.Advance(time.Second)
+
+	tick, ok = received(ticker.C())
+	assert.True(t, ok)
+	assert.Equal(t, epoch.Add(time.Second*7), tick)
+
+	ticker.Stop()
+
+	assert.Equal(t, 0, f.Waiters())
+
+	f.Advance(time.Second)
+
+	_, ok = received(ticker.C())
+	assert.False(t, ok)
+}
+
+func TestFakeTickerNonPositive(t *testing.T) {
+	assert.Panics(t, func() {
+		NewFake(epoch).NewTicker(0)
+	})
+}
+
+func TestFakeTimer(t *testing.T) {
+	f := NewFake(epoch)
+
+	timer := f.NewTimer(time.Second)
+
+	f.Set(epoch.Add(time.Second * 2))
+
+	tick, ok := received(timer.C())
+	assert.True(t, ok)
+	assert.Equal(t, epoch.Add(time.Second*2), tick)
+	assert.Equal(t, 0, f.Waiters())
+	assert.False(t, timer.Stop())
+
+	timer = f.NewTimer(time.Second)
+
+	assert.True(t, timer.Stop())
+
+	f.Advance(time.Second)
+
+	_, ok = received(timer.C())
+	assert.False(t, ok)
+}
+
+func TestFakeTimerExpired(t *testing.T) {
+	f := NewFake(epoch)
+
+	timer := f.NewTimer(0)
+
+	_, ok := received(timer.C())
+	assert.True(t, ok)
+}
+
+func TestFakeBlockUntil(t *testing.T) {
+	f := NewFake(epoch)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		f.BlockUntil(2)
+	}()
+
+	f.NewTimer(time.Second)
+	f.NewTicker(time.Second)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("BlockUntil did not return")
+	}
+}
@@ -0,0 +1,427 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/euskadi31/go-amplitude"
+)
+
+// commonInitialisms are written in upper case in Go identifiers.
+var commonInitialisms = map[string]bool{
+	"API":  true,
+	"HTML": true,
+	"HTTP": true,
+	"ID":   true,
+	"IP":   true,
+	"JSON": true,
+	"OS":   true,
+	"SKU":  true,
+	"SQL":  true,
+	"URI":  true,
+	"URL":  true,
+	"UUID": true,
+}
+
+type enumValue struct {
+	Name  string
+	Value string
+}
+
+type property struct {
+	Name        string
+	Field       string
+	Type        string
+	Description string
+	Pointer     bool
+	Nillable    bool
+	EnumType    string
+	EnumValues  []enumValue
+}
+
+type event struct {
+	Name        string
+	EventType   string
+	Description string
+	Properties  []*property
+}
+
+type model struct {
+	Package string
+	Plan    *amplitude.Plan
+	Events  []*event
+}
+
+var tmpl = template.Must(template.New("events").Parse(`// Code generated by amplitude-gen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import "github.com/euskadi31/go-amplitude"
+
+// Event types of the tracking plan.
+const (
+{{- range .Events }}
+	EventType{{ .Name }} = {{ printf "%q" .EventType }}
+{{- end }}
+)
+
+func trackingPlan() *amplitude.Plan {
+	return &amplitude.Plan{
+		Branch:  {{ printf "%q" .Plan.Branch }},
+		Source:  {{ printf "%q" .Plan.Source }},
+		Version: {{ printf "%q" .Plan.Version }},
+	}
+}
+{{ range $event := .Events }}
+{{- range .Properties }}{{ if .EnumType }}
+// {{ .EnumType }} values of the {{ printf "%q" .Name }} property of {{ printf "%q" $event.EventType }}.
+type {{ .EnumType }} string
+
+const (
+{{- $prop := . }}
+{{- range .EnumValues }}
+	{{ .Name }} {{ $prop.EnumType }} = {{ printf "%q" .Value }}
+{{- end }}
+)
+{{ end }}{{ end }}
+{{- if .Properties }}
+// {{ .Name }}Properties are the event properties of {{ printf "%q" .EventType }}.
+type {{ .Name }}Properties struct {
+{{- range .Properties }}
+{{- if .Description }}
+	// {{ .Field }} {{ .Description }}
+{{- end }}
+	{{ .Field }} {{ if .Pointer }}*{{ end }}{{ .Type }}
+{{- end }}
+}
+
+// New{{ .Name }} returns a {{ printf "%q" .EventType }} event.
+{{- if .Description }}
+// {{ .Description }}
+{{- end }}
+func New{{ .Name }}(props {{ .Name }}Properties) *amplitude.Event {
+	eventProperties := map[string]interface{}{}
+{{ range .Properties }}
+{{- if .Pointer }}
+	if props.{{ .Field }} != nil {
+		eventProperties[{{ printf "%q" .Name }}] = *props.{{ .Field }}
+	}
+{{ else if .Nillable }}
+	if props.{{ .Field }} != nil {
+		eventProperties[{{ printf "%q" .Name }}] = props.{{ .Field }}
+	}
+{{ else }}
+	eventProperties[{{ printf "%q" .Name }}] = props.{{ .Field }}
+{{ end }}
+{{- end }}
+	return &amplitude.Event{
+		EventType:       EventType{{ .Name }},
+		EventProperties: eventProperties,
+		Plan:            trackingPlan(),
+	}
+}
+{{ else }}
+// New{{ .Name }} returns a {{ printf "%q" .EventType }} event.
+{{- if .Description }}
+// {{ .Description }}
+{{- end }}
+func New{{ .Name }}() *amplitude.Event {
+	return &amplitude.Event{
+		EventType: EventType{{ .Name }},
+		Plan:      trackingPlan(),
+	}
+}
+{{ end }}
+{{- end }}`))
+
+// Generate returns the Go source of the event constructors of plan.
+func Generate(plan *amplitude.TrackingPlan, pkg string) ([]byte, error) {
+	m := &model{
+		Package: pkg,
+		Plan:    plan.Metadata(),
+	}
+
+	names := map[string]string{}
+	decls := map[string]string{}
+
+	eventTypes := make([]string, 0, len(plan.Events))
+
+	for eventType := range plan.Events {
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	sort.Strings(eventTypes)
+
+	for _, eventType := range eventTypes {
+		name := identifier(eventType)
+		if name == "" {
+			return nil, fmt.Errorf("event type %q: cannot be converted to a Go identifier", eventType)
+		}
+
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("event types %q and %q have the same Go name %s", other, eventType, name)
+		}
+
+		names[name] = eventType
+
+		evt, err := newEvent(name, eventType, plan.Events[eventType])
+		if err != nil {
+			return nil, err
+		}
+
+		if err := declare(decls, evt); err != nil {
+			return nil, err
+		}
+
+		m.Events = append(m.Events, evt)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := tmpl.Execute(buf, m); err != nil {
+		return nil, fmt.Errorf("execute template failed: %w", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format source failed: %w", err)
+	}
+
+	return src, nil
+}
+
+func newEvent(name string, eventType string, schema *amplitude.EventSchema) (*event, error) {
+	evt := &event{
+		Name:      name,
+		EventType: eventType,
+	}
+
+	if schema == nil {
+		return evt, nil
+	}
+
+	evt.Description = comment(schema.Description)
+
+	if schema.EventProperties == nil {
+		return evt, nil
+	}
+
+	required := map[string]bool{}
+
+	for _, name := range schema.EventProperties.Required {
+		required[name] = true
+	}
+
+	propNames := make([]string, 0, len(schema.EventProperties.Properties))
+
+	for propName := range schema.EventProperties.Properties {
+		propNames = append(propNames, propName)
+	}
+
+	sort.Strings(propNames)
+
+	fields := map[string]string{}
+
+	for _, propName := range propNames {
+		field := identifier(propName)
+		if field == "" {
+			return nil, fmt.Errorf("event type %q: property %q cannot be converted to a Go identifier", eventType, propName)
+		}
+
+		if other, ok := fields[field]; ok {
+			return nil, fmt.Errorf("event type %q: properties %q and %q have the same Go name %s", eventType, other, propName, field)
+		}
+
+		fields[field] = propName
+
+		propSchema := schema.EventProperties.Properties[propName]
+
+		prop := &property{
+			Name:        propName,
+			Field:       field,
+			Type:        goType(propSchema),
+			Description: comment(propSchema.Description),
+		}
+
+		if values := stringEnum(propSchema); len(values) > 0 {
+			prop.EnumType = name + field
+			prop.Type = prop.EnumType
+
+			consts := map[string]string{}
+
+			for _, value := range values {
+				id := identifier(value)
+
+				if other, ok := consts[id]; ok {
+					return nil, fmt.Errorf("event type %q: property %q: values %q and %q have the same Go name %s", eventType, propName, other, value, prop.EnumType+id)
+				}
+
+				consts[id] = value
+
+				prop.EnumValues = append(prop.EnumValues, enumValue{
+					Name:  prop.EnumType + id,
+					Value: value,
+				})
+			}
+		}
+
+		nillable := strings.HasPrefix(prop.Type, "[]") || strings.HasPrefix(prop.Type, "map[") || prop.Type == "interface{}"
+
+		if !required[propName] {
+			prop.Pointer = !nillable
+			prop.Nillable = nillable
+		}
+
+		evt.Properties = append(evt.Properties, prop)
+	}
+
+	return evt, nil
+}
+
+// declare records the Go names declared for evt in decls, they must be unique
+// in the package, e.g. the enum type of a "properties" property and the
+// properties struct of its event.
+func declare(decls map[string]string, evt *event) error {
+	add := func(name string, decl string) error {
+		if other, ok := decls[name]; ok {
+			return fmt.Errorf("%s and %s have the same Go name %s", other, decl, name)
+		}
+
+		decls[name] = decl
+
+		return nil
+	}
+
+	if err := add("EventType"+evt.Name, fmt.Sprintf("the event type constant of %q", evt.EventType)); err != nil {
+		return err
+	}
+
+	if err := add("New"+evt.Name, fmt.Sprintf("the constructor of %q", evt.EventType)); err != nil {
+		return err
+	}
+
+	if len(evt.Properties) > 0 {
+		if err := add(evt.Name+"Properties", fmt.Sprintf("the properties of %q", evt.EventType)); err != nil {
+			return err
+		}
+	}
+
+	for _, prop := range evt.Properties {
+		if prop.EnumType == "" {
+			continue
+		}
+
+		if err := add(prop.EnumType, fmt.Sprintf("the enum of property %q of %q", prop.Name, evt.EventType)); err != nil {
+			return err
+		}
+
+		for _, value := range prop.EnumValues {
+			if err := add(value.Name, fmt.Sprintf("the value %q of property %q of %q", value.Value, prop.Name, evt.EventType)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// stringEnum returns the values of a string enum.
+func stringEnum(schema *amplitude.Schema) []string {
+	if len(schema.Enum) == 0 || goType(schema) != "string" {
+		return nil
+	}
+
+	values := make([]string, 0, len(schema.Enum))
+
+	for _, v := range schema.Enum {
+		s, ok := v.(string)
+		if !ok || identifier(s) == "" {
+			return nil
+		}
+
+		values = append(values, s)
+	}
+
+	return values
+}
+
+func goType(schema *amplitude.Schema) string {
+	types := make([]string, 0, len(schema.Types))
+
+	for _, t := range schema.Types {
+		if t != amplitude.SchemaTypeNull {
+			types = append(types, t)
+		}
+	}
+
+	if len(types) != 1 {
+		return "interface{}"
+	}
+
+	switch types[0] {
+	case amplitude.SchemaTypeString:
+		return "string"
+	case amplitude.SchemaTypeInteger:
+		return "int"
+	case amplitude.SchemaTypeNumber:
+		return "float64"
+	case amplitude.SchemaTypeBoolean:
+		return "bool"
+	case amplitude.SchemaTypeObject:
+		return "map[string]interface{}"
+	case amplitude.SchemaTypeArray:
+		if schema.Items == nil {
+			return "[]interface{}"
+		}
+
+		return "[]" + goType(schema.Items)
+	}
+
+	return "interface{}"
+}
+
+// identifier converts s to an exported Go identifier, e.g. "song_id" to "SongID".
+func identifier(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+
+	for _, word := range words {
+		upper := strings.ToUpper(word)
+
+		if commonInitialisms[upper] {
+			b.WriteString(upper)
+
+			continue
+		}
+
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+
+		b.WriteString(string(runes))
+	}
+
+	id := b.String()
+
+	if id != "" && !unicode.IsLetter([]rune(id)[0]) {
+		id = "X" + id
+	}
+
+	return id
+}
+
+// comment returns s on a single line.
+func comment(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
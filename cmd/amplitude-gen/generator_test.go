@@ -0,0 +1,175 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	plan, err := amplitude.LoadTrackingPlanFile("../../testdata/tracking_plan.json")
+	assert.NoError(t, err)
+
+	src, err := Generate(plan, "events")
+	assert.NoError(t, err)
+
+	expected, err := os.ReadFile("testdata/events.golden")
+	assert.NoError(t, err)
+
+	assert.Equal(t, string(expected), string(src))
+
+	_, err = parser.ParseFile(token.NewFileSet(), "events.go", src, parser.AllErrors)
+	assert.NoError(t, err)
+}
+
+func TestGenerateWithoutProperties(t *testing.T) {
+	src, err := Generate(&amplitude.TrackingPlan{
+		Events: map[string]*amplitude.EventSchema{
+			"app.opened": nil,
+		},
+	}, "events")
+	assert.NoError(t, err)
+
+	assert.Contains(t, string(src), "func NewAppOpened() *amplitude.Event {")
+}
+
+func TestGenerateNameCollision(t *testing.T) {
+	_, err := Generate(&amplitude.TrackingPlan{
+		Events: map[string]*amplitude.EventSchema{
+			"user.created": nil,
+			"User Created": nil,
+		},
+	}, "events")
+	assert.EqualError(t, err, `event types "User Created" and "user.created" have the same Go name UserCreated`)
+
+	_, err = Generate(&amplitude.TrackingPlan{
+		Events: map[string]*amplitude.EventSchema{
+			"...": nil,
+		},
+	}, "events")
+	assert.Error(t, err)
+}
+
+func TestGenerateEnumCollision(t *testing.T) {
+	plan, err := amplitude.LoadTrackingPlan(strings.NewReader(`{
+		"events": {
+			"Song Played": {
+				"event_properties": {
+					"type": "object",
+					"properties": {
+						"quality": {"type": "string", "enum": ["a-b", "a_b"]}
+					}
+				}
+			}
+		}
+	}`))
+	assert.NoError(t, err)
+
+	_, err = Generate(plan, "events")
+	assert.EqualError(t, err, `event type "Song Played": property "quality": values "a-b" and "a_b" have the same Go name SongPlayedQualityAB`)
+}
+
+func TestGenerateTypeCollision(t *testing.T) {
+	for schema, expected := range map[string]string{
+		// The enum type of the "properties" property is the properties struct of the event.
+		`{
+			"events": {
+				"Song Played": {
+					"event_properties": {
+						"type": "object",
+						"properties": {
+							"properties": {"type": "string", "enum": ["low", "high"]},
+							"quality": {"type": "string"}
+						}
+					}
+				}
+			}
+		}`: `the properties of "Song Played" and the enum of property "properties" of "Song Played" have the same Go name SongPlayedProperties`,
+		// The enum types of two events.
+		`{
+			"events": {
+				"Song": {
+					"event_properties": {
+						"type": "object",
+						"properties": {
+							"played_quality": {"type": "string", "enum": ["low"]}
+						}
+					}
+				},
+				"Song Played": {
+					"event_properties": {
+						"type": "object",
+						"properties": {
+							"quality": {"type": "string", "enum": ["high"]}
+						}
+					}
+				}
+			}
+		}`: `the enum of property "played_quality" of "Song" and the enum of property "quality" of "Song Played" have the same Go name SongPlayedQuality`,
+		// An enum value and the event type constant of another event.
+		`{
+			"events": {
+				"Event": {
+					"event_properties": {
+						"type": "object",
+						"properties": {
+							"type": {"type": "string", "enum": ["song_played"]}
+						}
+					}
+				},
+				"Song Played": null
+			}
+		}`: `the value "song_played" of property "type" of "Event" and the event type constant of "Song Played" have the same Go name EventTypeSongPlayed`,
+	} {
+		plan, err := amplitude.LoadTrackingPlan(strings.NewReader(schema))
+		assert.NoError(t, err)
+
+		_, err = Generate(plan, "events")
+		assert.EqualError(t, err, expected)
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	for input, expected := range map[string]string{
+		"song_id":      "SongID",
+		"Song Played":  "SongPlayed",
+		"user.created": "UserCreated",
+		"page-url":     "PageURL",
+		"3d_view":      "X3dView",
+		"$set":         "Set",
+		"":             "",
+	} {
+		assert.Equal(t, expected, identifier(input), input)
+	}
+}
+
+func TestRun(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "events.go")
+
+	assert.NoError(t, run("../../testdata/tracking_plan.json", "events", output))
+
+	src, err := os.ReadFile(output)
+	assert.NoError(t, err)
+
+	expected, err := os.ReadFile("testdata/events.golden")
+	assert.NoError(t, err)
+
+	assert.Equal(t, string(expected), string(src))
+
+	info, err := os.Stat(output)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+
+	assert.Error(t, run("", "events", output))
+	assert.Error(t, run("testdata/not_found.json", "events", output))
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Command amplitude-gen generates type-safe event constructors from a tracking plan.
+//
+// Usage:
+//
+//	//go:generate go run github.com/euskadi31/go-amplitude/cmd/amplitude-gen -plan tracking_plan.json -package events -output events.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/euskadi31/go-amplitude"
+)
+
+func main() {
+	planFile := flag.String("plan", "", "tracking plan JSON file")
+	pkg := flag.String("package", "events", "package name of the generated file")
+	output := flag.String("output", "", "output file, defaults to stdout")
+
+	flag.Parse()
+
+	if err := run(*planFile, *pkg, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "amplitude-gen: %v\n", err)
+
+		os.Exit(1)
+	}
+}
+
+func run(planFile string, pkg string, output string) error {
+	if planFile == "" {
+		return errors.New("missing -plan flag")
+	}
+
+	plan, err := amplitude.LoadTrackingPlanFile(planFile)
+	if err != nil {
+		return err
+	}
+
+	src, err := Generate(plan, pkg)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		if _, err := os.Stdout.Write(src); err != nil {
+			return fmt.Errorf("write output failed: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(output, src, 0o644); err != nil { //nolint:gosec // generated source files are not secret
+		return fmt.Errorf("write output failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,273 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package cohorts implements a client of the Amplitude Behavioral Cohorts API.
+// see: https://amplitude.com/docs/apis/analytics/behavioral-cohorts
+package cohorts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/internal/api"
+)
+
+const (
+	StandardEndpoint    = api.StandardEndpoint
+	EUResidencyEndpoint = api.EUResidencyEndpoint
+)
+
+// ErrDownloadFailed is returned by Download when the download request failed or was cancelled.
+var ErrDownloadFailed = errors.New("cohort download failed")
+
+// IDType of the IDs of uploaded cohorts.
+type IDType string
+
+const (
+	IDTypeAmplitudeID IDType = "BY_AMP_ID"
+	IDTypeUserID      IDType = "BY_USER_ID"
+)
+
+// Operation of a membership update.
+type Operation string
+
+const (
+	OperationAdd    Operation = "ADD"
+	OperationRemove Operation = "REMOVE"
+)
+
+// Cohort struct.
+type Cohort struct {
+	ID           string   `json:"id"`
+	AppID        int64    `json:"appId"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Size         int64    `json:"size"`
+	Archived     bool     `json:"archived"`
+	Published    bool     `json:"published"`
+	Owners       []string `json:"owners"`
+	Type         string   `json:"type"`
+	LastComputed int64    `json:"lastComputed"`
+	LastMod      int64    `json:"lastMod"`
+}
+
+// Client of the Behavioral Cohorts API.
+type Client struct {
+	api          *api.Client
+	pollInterval time.Duration
+	maxWait      time.Duration
+}
+
+// New Behavioral Cohorts API client authenticated with the API key and secret key of the project.
+func New(apiKey string, secretKey string, opts ...Option) *Client {
+	c := &Client{
+		api:          api.New(StandardEndpoint),
+		pollInterval: time.Second * 5,
+		maxWait:      time.Minute * 30,
+	}
+
+	// Cohort files can be large.
+	c.api.Timeout = time.Minute * 10
+	c.api.Authorize = api.BasicAuth(apiKey, secretKey)
+
+	api.Apply(c, c.api, opts...)
+
+	return c
+}
+
+// List returns the cohorts of the project.
+func (c *Client) List(ctx context.Context) ([]*Cohort, error) {
+	resp := &struct {
+		Cohorts []*Cohort `json:"cohorts"`
+	}{}
+
+	if err := c.api.JSON(ctx, &api.Request{
+		Method: http.MethodGet,
+		Path:   "/api/3/cohorts",
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Cohorts, nil
+}
+
+// Upload creates a cohort, or replaces the members of ExistingCohortID.
+type Upload struct {
+	Name             string   `json:"name"`
+	AppID            int64    `json:"app_id"`
+	IDType           IDType   `json:"id_type"`
+	IDs              []string `json:"ids"`
+	Owner            string   `json:"owner"`
+	Published        bool     `json:"published"`
+	ExistingCohortID string   `json:"existing_cohort_id,omitempty"`
+}
+
+// Upload uploads a cohort and returns its ID.
+func (c *Client) Upload(ctx context.Context, upload *Upload) (string, error) {
+	req, err := api.NewJSONRequest(http.MethodPost, "/api/3/cohorts/upload", upload)
+	if err != nil {
+		return "", err
+	}
+
+	resp := &struct {
+		CohortID string `json:"cohortId"`
+	}{}
+
+	if err := c.api.JSON(ctx, req, resp); err != nil {
+		return "", err
+	}
+
+	return resp.CohortID, nil
+}
+
+// Membership adds or removes IDs from a cohort.
+type Membership struct {
+	IDs       []string  `json:"ids"`
+	IDType    IDType    `json:"id_type"`
+	Operation Operation `json:"operation"`
+}
+
+// MembershipResult struct.
+type MembershipResult struct {
+	Operation  Operation `json:"operation"`
+	SkippedIDs []string  `json:"skipped_ids"`
+}
+
+// UpdateMembership applies the memberships to the cohort in order, invalid IDs are skipped.
+func (c *Client) UpdateMembership(ctx context.Context, cohortID string, memberships ...*Membership) ([]*MembershipResult, error) {
+	req, err := api.NewJSONRequest(http.MethodPost, "/api/3/cohorts/membership", &struct {
+		CohortID       string        `json:"cohort_id"`
+		SkipInvalidIDs bool          `json:"skip_invalid_ids"`
+		Memberships    []*Membership `json:"memberships"`
+	}{
+		CohortID:       cohortID,
+		SkipInvalidIDs: true,
+		Memberships:    memberships,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &struct {
+		Results []*MembershipResult `json:"memberships_result"`
+	}{}
+
+	if err := c.api.JSON(ctx, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Results, nil
+}
+
+// Download requests the members of a cohort, waits until the file is ready
+// and returns an iterator over its members. When props is true the members
+// are returned with their user properties.
+func (c *Client) Download(ctx context.Context, cohortID string, props bool) (*MemberIterator, error) {
+	requestID, err := c.requestDownload(ctx, cohortID, props)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.wait(ctx, requestID); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.api.Do(ctx, &api.Request{
+		Method: http.MethodGet,
+		Path:   "/api/5/cohorts/request/" + url.PathEscape(requestID) + "/file",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	it, err := newMemberIterator(resp.Body)
+	if err != nil {
+		api.CloseBody(resp)
+
+		return nil, err
+	}
+
+	return it, nil
+}
+
+const (
+	statusCompleted = "JOB COMPLETED"
+	statusFailed    = "JOB FAILED"
+	statusCancelled = "JOB CANCELLED"
+	statusCanceled  = "JOB CANCELED"
+)
+
+type downloadStatus struct {
+	RequestID   string `json:"request_id"`
+	CohortID    string `json:"cohort_id"`
+	AsyncStatus string `json:"async_status"`
+}
+
+func (c *Client) requestDownload(ctx context.Context, cohortID string, props bool) (string, error) {
+	q := url.Values{
+		"props": {"0"},
+	}
+
+	if props {
+		q.Set("props", "1")
+	}
+
+	status := &downloadStatus{}
+
+	if err := c.api.JSON(ctx, &api.Request{
+		Method: http.MethodGet,
+		Path:   "/api/5/cohorts/request/" + url.PathEscape(cohortID),
+		Query:  q,
+	}, status); err != nil {
+		return "", err
+	}
+
+	if status.RequestID == "" {
+		return "", fmt.Errorf("cohort %s: no request id returned", cohortID)
+	}
+
+	return status.RequestID, nil
+}
+
+// wait polls the status of the download request until it is completed, for
+// at most maxWait when ctx has no deadline.
+func (c *Client) wait(ctx context.Context, requestID string) error {
+	if _, ok := ctx.Deadline(); !ok && c.maxWait > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.maxWait)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		status := &downloadStatus{}
+
+		if err := c.api.JSON(ctx, &api.Request{
+			Method: http.MethodGet,
+			Path:   "/api/5/cohorts/request-status/" + url.PathEscape(requestID),
+		}, status); err != nil {
+			return err
+		}
+
+		switch status.AsyncStatus {
+		case statusCompleted:
+			return nil
+		case statusFailed, statusCancelled, statusCanceled:
+			return fmt.Errorf("%w: request %s: %s", ErrDownloadFailed, requestID, status.AsyncStatus)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait cohort download failed: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package cohorts
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *Client) {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "key", user)
+		assert.Equal(t, "secret", pass)
+
+		handler(w, r)
+	}))
+
+	return ts, New(
+		"key",
+		"secret",
+		WithURL(ts.URL),
+		WithPollInterval(time.Millisecond),
+		WithRetryInterval(time.Millisecond),
+	)
+}
+
+func TestClientList(t *testing.T) {
+	ts, c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/3/cohorts", r.URL.Path)
+
+		w.Write([]byte(`{"cohorts":[{"id":"abc","appId":123,"name":"Power users","size":42,"published":true,"owners":["me@example.com"],"lastComputed":1792404000000}]}`))
+	})
+	defer ts.Close()
+
+	cohorts, err := c.List(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*Cohort{{
+		ID:           "abc",
+		AppID:        123,
+		Name:         "Power users",
+		Size:         42,
+		Published:    true,
+		Owners:       []string{"me@example.com"},
+		LastComputed: 1792404000000,
+	}}, cohorts)
+}
+
+func TestClientUpload(t *testing.T) {
+	ts, c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/3/cohorts/upload", r.URL.Path)
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		assert.JSONEq(t, `{"name":"CRM segment","app_id":123,"id_type":"BY_USER_ID","ids":["user-1","user-2"],"owner":"me@example.com","published":true}`, string(b))
+
+		w.Write([]byte(`{"cohortId":"abc"}`))
+	})
+	defer ts.Close()
+
+	id, err := c.Upload(context.Background(), &Upload{
+		Name:      "CRM segment",
+		AppID:     123,
+		IDType:    IDTypeUserID,
+		IDs:       []string{"user-1", "user-2"},
+		Owner:     "me@example.com",
+		Published: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", id)
+}
+
+func TestClientUploadError(t *testing.T) {
+	ts, c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid app id", http.StatusBadRequest)
+	})
+	defer ts.Close()
+
+	_, err := c.Upload(context.Background(), &Upload{})
+	assert.EqualError(t, err, "400: invalid app id")
+}
+
+func TestClientUpdateMembership(t *testing.T) {
+	ts, c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/3/cohorts/membership", r.URL.Path)
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		assert.JSONEq(t, `{"cohort_id":"abc","skip_invalid_ids":true,"memberships":[{"ids":["user-1"],"id_type":"BY_USER_ID","operation":"ADD"},{"ids":["user-2"],"id_type":"BY_USER_ID","operation":"REMOVE"}]}`, string(b))
+
+		w.Write([]byte(`{"cohort_id":"abc","memberships_result":[{"operation":"ADD","skipped_ids":[]},{"operation":"REMOVE","skipped_ids":["user-2"]}]}`))
+	})
+	defer ts.Close()
+
+	results, err := c.UpdateMembership(
+		context.Background(),
+		"abc",
+		&Membership{IDs: []string{"user-1"}, IDType: IDTypeUserID, Operation: OperationAdd},
+		&Membership{IDs: []string{"user-2"}, IDType: IDTypeUserID, Operation: OperationRemove},
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*MembershipResult{
+		{Operation: OperationAdd, SkippedIDs: []string{}},
+		{Operation: OperationRemove, SkippedIDs: []string{"user-2"}},
+	}, results)
+}
+
+func TestClientDownload(t *testing.T) {
+	polls := 0
+
+	ts, c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/5/cohorts/request/abc":
+			assert.Equal(t, "1", r.URL.Query().Get("props"))
+
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"request_id":"req-1","cohort_id":"abc"}`))
+		case "/api/5/cohorts/request-status/req-1":
+			polls++
+
+			if polls < 3 {
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte(`{"request_id":"req-1","cohort_id":"abc","async_status":"JOB INPROGRESS"}`))
+
+				return
+			}
+
+			w.Write([]byte(`{"request_id":"req-1","cohort_id":"abc","async_status":"JOB COMPLETED"}`))
+		case "/api/5/cohorts/request/req-1/file":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte("\"amplitude_id\",\"user_id\",\"plan\"\n123,user-1,premium\n456,user-2,free\n"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer ts.Close()
+
+	it, err := c.Download(context.Background(), "abc", true)
+	assert.NoError(t, err)
+
+	var members []*Member
+
+	for it.Next() {
+		members = append(members, it.Member())
+	}
+
+	assert.NoError(t, it.Err())
+	assert.NoError(t, it.Close())
+
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, []*Member{
+		{AmplitudeID: "123", UserID: "user-1", Properties: map[string]string{"plan": "premium"}},
+		{AmplitudeID: "456", UserID: "user-2", Properties: map[string]string{"plan": "free"}},
+	}, members)
+}
+
+func TestClientDownloadCanceled(t *testing.T) {
+	ts, c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"request_id":"req-1","cohort_id":"abc","async_status":"JOB INPROGRESS"}`))
+	})
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	_, err := c.Download(ctx, "abc", false)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClientDownloadMaxWait(t *testing.T) {
+	ts, c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"request_id":"req-1","cohort_id":"abc","async_status":"JOB INPROGRESS"}`))
+	})
+	defer ts.Close()
+
+	WithMaxWait(time.Millisecond*50)(c, c.api)
+
+	_, err := c.Download(context.Background(), "abc", false)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClientDownloadFailed(t *testing.T) {
+	for _, status := range []string{"JOB FAILED", "JOB CANCELLED"} {
+		ts, c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"request_id":"req-1","cohort_id":"abc","async_status":"` + status + `"}`))
+		})
+
+		_, err := c.Download(context.Background(), "abc", false)
+		assert.ErrorIs(t, err, ErrDownloadFailed)
+		assert.ErrorContains(t, err, status)
+
+		ts.Close()
+	}
+}
+
+func TestClientDownloadWithoutRequestID(t *testing.T) {
+	ts, c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	defer ts.Close()
+
+	_, err := c.Download(context.Background(), "abc", false)
+	assert.EqualError(t, err, "cohort abc: no request id returned")
+}
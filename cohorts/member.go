@@ -0,0 +1,127 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package cohorts
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Member of a cohort.
+type Member struct {
+	AmplitudeID string
+	UserID      string
+
+	// Properties are the user properties, when requested.
+	Properties map[string]string
+}
+
+// MemberIterator streams the members of a cohort file.
+//
+//	for it.Next() {
+//		member := it.Member()
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type MemberIterator struct {
+	body    io.ReadCloser
+	reader  *csv.Reader
+	columns []string
+	member  *Member
+	err     error
+}
+
+func newMemberIterator(body io.ReadCloser) (*MemberIterator, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("read cohort file header failed: %w", err)
+	}
+
+	columns := make([]string, len(header))
+
+	for i, name := range header {
+		columns[i] = strings.Trim(name, " \t\"")
+	}
+
+	return &MemberIterator{
+		body:    body,
+		reader:  reader,
+		columns: columns,
+	}, nil
+}
+
+// Next advances to the next member, it returns false when there are no more
+// members or an error occurred.
+func (it *MemberIterator) Next() bool {
+	if it.err != nil || len(it.columns) == 0 {
+		return false
+	}
+
+	record, err := it.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return false
+	}
+
+	if err != nil {
+		it.err = fmt.Errorf("read cohort file failed: %w", err)
+
+		return false
+	}
+
+	member := &Member{}
+
+	for i, value := range record {
+		if i >= len(it.columns) {
+			break
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch it.columns[i] {
+		case "amplitude_id":
+			member.AmplitudeID = value
+		case "user_id":
+			member.UserID = value
+		default:
+			if member.Properties == nil {
+				member.Properties = map[string]string{}
+			}
+
+			member.Properties[it.columns[i]] = value
+		}
+	}
+
+	it.member = member
+
+	return true
+}
+
+// Member returns the current member.
+func (it *MemberIterator) Member() *Member {
+	return it.member
+}
+
+// Err returns the error that stopped the iteration.
+func (it *MemberIterator) Err() error {
+	return it.err
+}
+
+// Close closes the cohort file.
+func (it *MemberIterator) Close() error {
+	if err := it.body.Close(); err != nil {
+		return fmt.Errorf("close cohort file failed: %w", err)
+	}
+
+	return nil
+}
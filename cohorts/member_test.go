@@ -0,0 +1,60 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package cohorts
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberIterator(t *testing.T) {
+	it, err := newMemberIterator(io.NopCloser(strings.NewReader("\tamplitude_id,user_id\n123, user-1\n456,\n789,user-3,extra\n")))
+	assert.NoError(t, err)
+
+	var members []*Member
+
+	for it.Next() {
+		members = append(members, it.Member())
+	}
+
+	assert.NoError(t, it.Err())
+	assert.NoError(t, it.Close())
+
+	assert.Equal(t, []*Member{
+		{AmplitudeID: "123", UserID: "user-1"},
+		{AmplitudeID: "456"},
+		{AmplitudeID: "789", UserID: "user-3"},
+	}, members)
+}
+
+func TestMemberIteratorEmpty(t *testing.T) {
+	it, err := newMemberIterator(io.NopCloser(strings.NewReader("")))
+	assert.NoError(t, err)
+
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
+func TestMemberIteratorReadError(t *testing.T) {
+	_, err := newMemberIterator(io.NopCloser(&errReader{}))
+	assert.Error(t, err)
+
+	it, err := newMemberIterator(io.NopCloser(io.MultiReader(strings.NewReader("amplitude_id\n123\n"), &errReader{})))
+	assert.NoError(t, err)
+
+	assert.True(t, it.Next())
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), io.ErrUnexpectedEOF)
+	assert.False(t, it.Next())
+}
+
+type errReader struct{}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
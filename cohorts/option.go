@@ -0,0 +1,36 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package cohorts
+
+import (
+	"time"
+
+	"github.com/euskadi31/go-amplitude/internal/api"
+)
+
+// Option configures a Client.
+type Option = api.Option[Client]
+
+// The options shared by the REST API clients.
+var (
+	WithURL           = api.WithURL[Client]
+	WithTimeout       = api.WithTimeout[Client]
+	WithMaxRetry      = api.WithMaxRetry[Client]
+	WithRetryInterval = api.WithRetryInterval[Client]
+	WithHTTPClient    = api.WithHTTPClient[Client]
+)
+
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *Client, _ *api.Client) {
+		c.pollInterval = interval
+	}
+}
+
+// WithMaxWait bounds the wait of Download for the file when its context has no deadline.
+func WithMaxWait(maxWait time.Duration) Option {
+	return func(c *Client, _ *api.Client) {
+		c.maxWait = maxWait
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"sync"
+)
+
+// ConsentLevel defines how a user can be tracked.
+type ConsentLevel int
+
+const (
+	// ConsentGranted allows tracking.
+	ConsentGranted ConsentLevel = iota
+
+	// ConsentLimited allows tracking without the IP and location fields.
+	ConsentLimited
+
+	// ConsentDenied drops the events of the user.
+	ConsentDenied
+)
+
+// Consent of a user.
+type Consent struct {
+	Level ConsentLevel
+
+	// Categories of events allowed, all the categories are allowed when nil.
+	Categories []string
+}
+
+func (c Consent) allows(category string) bool {
+	if c.Categories == nil {
+		return true
+	}
+
+	for _, allowed := range c.Categories {
+		if allowed == category {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ConsentProvider returns the consent of users, it is consulted on Enqueue
+// and must be safe for concurrent use.
+type ConsentProvider interface {
+	Consent(ctx context.Context, userID string, deviceID string) Consent
+}
+
+// EventCategorizer returns the category of an event.
+type EventCategorizer func(event *Event) string
+
+// MemoryConsentProvider is a ConsentProvider storing consents in memory,
+// it can be updated at runtime.
+type MemoryConsentProvider struct {
+	mtx            sync.RWMutex
+	defaultConsent Consent
+	users          map[string]Consent
+	devices        map[string]Consent
+}
+
+var _ ConsentProvider = (*MemoryConsentProvider)(nil)
+
+// NewMemoryConsentProvider returns a MemoryConsentProvider returning defaultConsent for unknown users.
+func NewMemoryConsentProvider(defaultConsent Consent) *MemoryConsentProvider {
+	return &MemoryConsentProvider{
+		defaultConsent: copyConsent(defaultConsent),
+		users:          map[string]Consent{},
+		devices:        map[string]Consent{},
+	}
+}
+
+func copyConsent(consent Consent) Consent {
+	if consent.Categories != nil {
+		consent.Categories = append([]string{}, consent.Categories...)
+	}
+
+	return consent
+}
+
+// SetUser sets the consent of userID.
+func (p *MemoryConsentProvider) SetUser(userID string, consent Consent) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.users[userID] = copyConsent(consent)
+}
+
+// RemoveUser removes the consent of userID.
+func (p *MemoryConsentProvider) RemoveUser(userID string) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	delete(p.users, userID)
+}
+
+// SetDevice sets the consent of deviceID.
+func (p *MemoryConsentProvider) SetDevice(deviceID string, consent Consent) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.devices[deviceID] = copyConsent(consent)
+}
+
+// RemoveDevice removes the consent of deviceID.
+func (p *MemoryConsentProvider) RemoveDevice(deviceID string) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	delete(p.devices, deviceID)
+}
+
+// Consent returns the consent of userID, of deviceID when the user has none,
+// or the default consent.
+func (p *MemoryConsentProvider) Consent(_ context.Context, userID string, deviceID string) Consent {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	if consent, ok := p.users[userID]; ok && userID != "" {
+		return consent
+	}
+
+	if consent, ok := p.devices[deviceID]; ok && deviceID != "" {
+		return consent
+	}
+
+	return p.defaultConsent
+}
+
+// consentPlugin is a before plugin applying the consent of users.
+type consentPlugin struct {
+	provider   ConsentProvider
+	categorize EventCategorizer
+}
+
+func (p *consentPlugin) Type() PluginType {
+	return PluginTypeBefore
+}
+
+func (p *consentPlugin) Execute(ctx context.Context, event *Event) ([]*Event, error) {
+	consent := p.provider.Consent(ctx, event.UserID, event.DeviceID)
+
+	switch consent.Level {
+	case ConsentDenied:
+		return nil, nil
+	case ConsentLimited:
+		event.IP = ""
+		event.LocationLat = 0
+		event.LocationLng = 0
+		event.City = ""
+		event.Region = ""
+		event.DMA = ""
+	case ConsentGranted:
+	}
+
+	if p.categorize != nil && !consent.allows(p.categorize(event)) {
+		return nil, nil
+	}
+
+	return []*Event{event}, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryConsentProvider(t *testing.T) {
+	ctx := context.Background()
+
+	categories := []string{"essential"}
+
+	p := NewMemoryConsentProvider(Consent{Level: ConsentGranted})
+
+	assert.Equal(t, Consent{Level: ConsentGranted}, p.Consent(ctx, "user", "device"))
+
+	p.SetDevice("device", Consent{Level: ConsentLimited})
+
+	assert.Equal(t, Consent{Level: ConsentLimited}, p.Consent(ctx, "user", "device"))
+
+	p.SetUser("user", Consent{Level: ConsentGranted, Categories: categories})
+
+	categories[0] = "marketing"
+
+	assert.Equal(t, Consent{Level: ConsentGranted, Categories: []string{"essential"}}, p.Consent(ctx, "user", "device"))
+	assert.Equal(t, Consent{Level: ConsentLimited}, p.Consent(ctx, "", "device"))
+
+	p.RemoveUser("user")
+
+	assert.Equal(t, Consent{Level: ConsentLimited}, p.Consent(ctx, "user", "device"))
+
+	p.RemoveDevice("device")
+
+	assert.Equal(t, Consent{Level: ConsentGranted}, p.Consent(ctx, "user", "device"))
+}
+
+func TestMemoryConsentProviderConcurrency(t *testing.T) {
+	p := NewMemoryConsentProvider(Consent{Level: ConsentGranted})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+
+			p.SetUser(fmt.Sprintf("user-%d", i), Consent{Level: ConsentDenied})
+		}(i)
+
+		go func(i int) {
+			defer wg.Done()
+
+			p.Consent(context.Background(), fmt.Sprintf("user-%d", i), "")
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, ConsentDenied, p.Consent(context.Background(), "user-9", "").Level)
+}
+
+func TestConsentPlugin(t *testing.T) {
+	ctx := context.Background()
+
+	provider := NewMemoryConsentProvider(Consent{Level: ConsentGranted})
+	provider.SetUser("denied", Consent{Level: ConsentDenied})
+	provider.SetUser("limited", Consent{Level: ConsentLimited})
+	provider.SetDevice("essential", Consent{Level: ConsentGranted, Categories: []string{"essential"}})
+
+	p := &consentPlugin{
+		provider: provider,
+		categorize: func(event *Event) string {
+			if event.EventType == "purchase" {
+				return "essential"
+			}
+
+			return "analytics"
+		},
+	}
+
+	assert.Equal(t, PluginTypeBefore, p.Type())
+
+	events, err := p.Execute(ctx, &Event{UserID: "denied", EventType: "purchase"})
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = p.Execute(ctx, &Event{
+		UserID:      "limited",
+		EventType:   "page.viewed",
+		IP:          "192.168.1.1",
+		LocationLat: 43.48,
+		LocationLng: -1.55,
+		City:        "Biarritz",
+		Country:     "France",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Event{{UserID: "limited", EventType: "page.viewed", Country: "France"}}, events)
+
+	events, err = p.Execute(ctx, &Event{DeviceID: "essential", EventType: "page.viewed"})
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = p.Execute(ctx, &Event{DeviceID: "essential", EventType: "purchase"})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	p.categorize = nil
+
+	events, err = p.Execute(ctx, &Event{DeviceID: "essential", EventType: "page.viewed"})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+}
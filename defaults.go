@@ -0,0 +1,77 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+
+	"github.com/euskadi31/go-amplitude/internal/properties"
+)
+
+type contextKey struct{}
+
+// ContextWithEventProperties returns a copy of ctx carrying event properties,
+// they are merged into the events enqueued with EnqueueContext.
+// Properties already carried by ctx are kept unless overridden.
+func ContextWithEventProperties(ctx context.Context, props map[string]interface{}) context.Context {
+	merged := make(map[string]interface{}, len(props))
+
+	for key, value := range EventPropertiesFromContext(ctx) {
+		merged[key] = value
+	}
+
+	for key, value := range props {
+		merged[key] = value
+	}
+
+	return context.WithValue(ctx, contextKey{}, merged)
+}
+
+// EventPropertiesFromContext returns the event properties carried by ctx.
+func EventPropertiesFromContext(ctx context.Context) map[string]interface{} {
+	props, _ := ctx.Value(contextKey{}).(map[string]interface{})
+
+	return props
+}
+
+// addContextProperties sets the event properties carried by ctx missing from event.
+func addContextProperties(ctx context.Context, event *Event) {
+	event.EventProperties = properties.Merge(event.EventProperties, EventPropertiesFromContext(ctx))
+}
+
+func mergeString(dst *string, src string) {
+	if *dst == "" {
+		*dst = src
+	}
+}
+
+// defaultsPlugin is a before plugin merging client level defaults into events:
+// the app, platform, OS, device, carrier and language fields, the event
+// properties, user properties and groups.
+type defaultsPlugin struct {
+	defaults *Event
+}
+
+func (p *defaultsPlugin) Type() PluginType {
+	return PluginTypeBefore
+}
+
+func (p *defaultsPlugin) Execute(_ context.Context, event *Event) ([]*Event, error) {
+	mergeString(&event.AppVersion, p.defaults.AppVersion)
+	mergeString(&event.Platform, p.defaults.Platform)
+	mergeString(&event.OSName, p.defaults.OSName)
+	mergeString(&event.OSVersion, p.defaults.OSVersion)
+	mergeString(&event.DeviceBrand, p.defaults.DeviceBrand)
+	mergeString(&event.DeviceManufacturer, p.defaults.DeviceManufacturer)
+	mergeString(&event.DeviceModel, p.defaults.DeviceModel)
+	mergeString(&event.Carrier, p.defaults.Carrier)
+	mergeString(&event.Language, p.defaults.Language)
+
+	event.EventProperties = properties.Merge(event.EventProperties, p.defaults.EventProperties)
+	event.UserProperties = properties.Merge(event.UserProperties, p.defaults.UserProperties)
+	event.Groups = properties.Merge(event.Groups, p.defaults.Groups)
+
+	return []*Event{event}, nil
+}
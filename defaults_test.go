@@ -0,0 +1,203 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWithEventProperties(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Nil(t, EventPropertiesFromContext(ctx))
+
+	ctx = ContextWithEventProperties(ctx, map[string]interface{}{
+		"request_id": "1",
+		"region":     "eu",
+	})
+
+	child := ContextWithEventProperties(ctx, map[string]interface{}{
+		"request_id": "2",
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"request_id": "1",
+		"region":     "eu",
+	}, EventPropertiesFromContext(ctx))
+
+	assert.Equal(t, map[string]interface{}{
+		"request_id": "2",
+		"region":     "eu",
+	}, EventPropertiesFromContext(child))
+}
+
+func TestAddContextProperties(t *testing.T) {
+	event := &Event{EventType: "user.created"}
+
+	addContextProperties(context.Background(), event)
+	assert.Nil(t, event.EventProperties)
+
+	ctx := ContextWithEventProperties(context.Background(), map[string]interface{}{
+		"request_id": "1",
+		"source":     "ctx",
+	})
+
+	event.EventProperties = map[string]interface{}{"source": "event"}
+
+	addContextProperties(ctx, event)
+
+	assert.Equal(t, map[string]interface{}{
+		"request_id": "1",
+		"source":     "event",
+	}, event.EventProperties)
+}
+
+func TestDefaultsPlugin(t *testing.T) {
+	p := &defaultsPlugin{
+		defaults: &Event{
+			AppVersion: "1.2.3",
+			Platform:   "server",
+			OSName:     "linux",
+			EventProperties: map[string]interface{}{
+				"region":  "eu-west-1",
+				"service": "api",
+			},
+			UserProperties: map[string]interface{}{
+				"tenant": "acme",
+			},
+		},
+	}
+
+	assert.Equal(t, PluginTypeBefore, p.Type())
+
+	props := map[string]interface{}{
+		"service": "worker",
+	}
+
+	events, err := p.Execute(context.Background(), &Event{
+		EventType:       "user.created",
+		Platform:        "ios",
+		EventProperties: props,
+	})
+	assert.NoError(t, err)
+
+	// The properties of the caller are left untouched.
+	assert.Equal(t, map[string]interface{}{"service": "worker"}, props)
+
+	assert.Equal(t, []*Event{{
+		EventType:  "user.created",
+		AppVersion: "1.2.3",
+		Platform:   "ios",
+		OSName:     "linux",
+		EventProperties: map[string]interface{}{
+			"region":  "eu-west-1",
+			"service": "worker",
+		},
+		UserProperties: map[string]interface{}{
+			"tenant": "acme",
+		},
+	}}, events)
+
+	events[0].UserProperties["tenant"] = "other"
+
+	assert.Equal(t, "acme", p.defaults.UserProperties["tenant"])
+}
+
+func TestClientWithDefaultsSharedProperties(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	c := start(
+		"foo",
+		WithURL(ts.URL),
+		WithInterval(time.Millisecond),
+		WithDefaults(&Event{
+			EventProperties: map[string]interface{}{"region": "eu-west-1"},
+			UserProperties:  map[string]interface{}{"tenant": "acme"},
+		}),
+	)
+
+	// The properties of the caller are shared by the events marshalled by the loop.
+	props := map[string]interface{}{"page": "home"}
+	userProps := map[string]interface{}{"plan": "pro"}
+
+	for i := 0; i < 100; i++ {
+		ctx := ContextWithEventProperties(context.Background(), map[string]interface{}{
+			fmt.Sprintf("request_%d", i): i,
+		})
+
+		assert.NoError(t, c.EnqueueContext(ctx, &Event{
+			EventType:       "page.viewed",
+			UserID:          "user-1",
+			EventProperties: props,
+			UserProperties:  userProps,
+		}))
+
+		// The events are spread over several flushes.
+		time.Sleep(time.Millisecond / 10)
+	}
+
+	assert.NoError(t, c.Close())
+
+	assert.Equal(t, map[string]interface{}{"page": "home"}, props)
+	assert.Equal(t, map[string]interface{}{"plan": "pro"}, userProps)
+}
+
+func TestClientEnqueueContext(t *testing.T) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer wg.Done()
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		defer r.Body.Close()
+
+		assert.Equal(t, `{"api_key":"foo","events":[{"event_type":"user.created","time":1643367217,"event_properties":{"region":"eu-west-1","request_id":"42","service":"api"},"app_version":"1.2.3"}]}`, string(b))
+	}))
+	defer ts.Close()
+
+	c := start(
+		"foo",
+		WithURL(ts.URL),
+		WithInterval(time.Millisecond*100),
+		WithDefaults(&Event{
+			AppVersion: "1.2.3",
+			EventProperties: map[string]interface{}{
+				"region":     "eu-west-1",
+				"request_id": "default",
+			},
+		}),
+	)
+	defer c.Close()
+
+	ctx := ContextWithEventProperties(context.Background(), map[string]interface{}{
+		"request_id": "42",
+		"service":    "worker",
+	})
+
+	err := c.EnqueueContext(ctx, &Event{
+		EventType: "user.created",
+		Timestamp: 1643367217,
+		EventProperties: map[string]interface{}{
+			"service": "api",
+		},
+	})
+	assert.NoError(t, err)
+
+	wg.Wait()
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// DryRunEnv is the environment variable enabling the dry-run mode when no
+// WithDryRun option is given: "ndjson" writes NDJSON to stderr, "1",
+// "true" or "pretty" write indented JSON to stderr.
+const DryRunEnv = "AMPLITUDE_DRY_RUN"
+
+// DebugEnv is the environment variable enabling the debug mode when no
+// WithDebug option is given, it accepts the values of DryRunEnv.
+const DebugEnv = "AMPLITUDE_DEBUG"
+
+// DryRunFormat defines how the payloads are written in dry-run and debug modes.
+type DryRunFormat int
+
+const (
+	// DryRunPretty writes each payload as indented JSON.
+	DryRunPretty DryRunFormat = iota
+
+	// DryRunNDJSON writes each payload on a single line.
+	DryRunNDJSON
+)
+
+// payloadWriter writes the payloads in dry-run and debug modes.
+type payloadWriter struct {
+	w      io.Writer
+	format DryRunFormat
+}
+
+// payloadWriterFromEnv returns the payload writer configured by the environment variable name, or nil.
+func payloadWriterFromEnv(name string) *payloadWriter {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(name))) {
+	case "1", "true", "pretty":
+		return &payloadWriter{w: os.Stderr, format: DryRunPretty}
+	case "ndjson":
+		return &payloadWriter{w: os.Stderr, format: DryRunNDJSON}
+	}
+
+	return nil
+}
+
+// maskedKeySuffix is the number of characters of the API key left visible by maskAPIKey.
+const maskedKeySuffix = 4
+
+// maskAPIKey hides the API key but its last characters, so the project stays identifiable.
+func maskAPIKey(key string) string {
+	if len(key) <= maskedKeySuffix*2 {
+		return "****"
+	}
+
+	return "****" + key[len(key)-maskedKeySuffix:]
+}
+
+// write writes the body of payload with a masked API key, the form encoded
+// payloads are written as a JSON object of their fields.
+func (d *payloadWriter) write(payload *Payload) error {
+	body := payload.Body
+
+	if strings.HasPrefix(payload.ContentType, "application/x-www-form-urlencoded") {
+		b, err := formToJSON(body)
+		if err != nil {
+			return err
+		}
+
+		body = b
+	}
+
+	body, err := maskPayload(body)
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+
+	switch d.format {
+	case DryRunPretty:
+		err = json.Indent(buf, body, "", "  ")
+	case DryRunNDJSON:
+		err = json.Compact(buf, body)
+	}
+
+	if err != nil {
+		return fmt.Errorf("format payload failed: %w", err)
+	}
+
+	buf.WriteByte('\n')
+
+	if _, err := d.w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write payload failed: %w", err)
+	}
+
+	return nil
+}
+
+// maskPayload replaces the api_key field of the JSON object body with its masked value.
+func maskPayload(body []byte) ([]byte, error) {
+	var obj map[string]json.RawMessage
+
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return nil, fmt.Errorf("format payload failed: %w", err)
+	}
+
+	raw, ok := obj["api_key"]
+	if !ok {
+		return body, nil
+	}
+
+	var key string
+
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return nil, fmt.Errorf("format payload failed: %w", err)
+	}
+
+	masked, err := json.Marshal(maskAPIKey(key))
+	if err != nil {
+		return nil, fmt.Errorf("format payload failed: %w", err)
+	}
+
+	obj["api_key"] = masked
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("format payload failed: %w", err)
+	}
+
+	return b, nil
+}
+
+// formToJSON converts a form to a JSON object, the JSON values are kept as is.
+func formToJSON(body []byte) ([]byte, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("parse form failed: %w", err)
+	}
+
+	obj := make(map[string]json.RawMessage, len(form))
+
+	for key := range form {
+		value := form.Get(key)
+
+		if json.Valid([]byte(value)) {
+			obj[key] = json.RawMessage(value)
+
+			continue
+		}
+
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("json encode form value failed: %w", err)
+		}
+
+		obj[key] = b
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("json encode form failed: %w", err)
+	}
+
+	return b, nil
+}
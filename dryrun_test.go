@@ -0,0 +1,173 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadWriterFromEnv(t *testing.T) {
+	for value, expected := range map[string]*payloadWriter{
+		"":       nil,
+		"0":      nil,
+		"1":      {w: os.Stderr, format: DryRunPretty},
+		"TRUE":   {w: os.Stderr, format: DryRunPretty},
+		"pretty": {w: os.Stderr, format: DryRunPretty},
+		"ndjson": {w: os.Stderr, format: DryRunNDJSON},
+	} {
+		t.Setenv(DryRunEnv, value)
+
+		assert.Equal(t, expected, payloadWriterFromEnv(DryRunEnv), value)
+	}
+}
+
+func TestPayloadWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	w := &payloadWriter{w: buf, format: DryRunNDJSON}
+
+	assert.NoError(t, w.write(&Payload{Body: []byte(`{"api_key": "foo", "events": []}`)}))
+	assert.NoError(t, w.write(&Payload{
+		ContentType: "application/x-www-form-urlencoded",
+		Body: []byte(url.Values{
+			"api_key": {"foo"},
+			"mapping": {`[{"user_id":"user-1","global_user_id":"global-1"}]`},
+		}.Encode()),
+	}))
+
+	assert.Equal(t, `{"api_key":"****","events":[]}
+{"api_key":"****","mapping":[{"user_id":"user-1","global_user_id":"global-1"}]}
+`, buf.String())
+
+	buf.Reset()
+
+	w.format = DryRunPretty
+
+	assert.NoError(t, w.write(&Payload{Body: []byte(`{"api_key":"foo","events":[]}`)}))
+	assert.Equal(t, "{\n  \"api_key\": \"****\",\n  \"events\": []\n}\n", buf.String())
+
+	assert.Error(t, w.write(&Payload{Body: []byte(`{`)}))
+	assert.Error(t, w.write(&Payload{ContentType: "application/x-www-form-urlencoded", Body: []byte(`%zz`)}))
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestPayloadWriterError(t *testing.T) {
+	w := &payloadWriter{w: errWriter{}, format: DryRunNDJSON}
+
+	assert.Error(t, w.write(&Payload{Body: []byte(`{}`)}))
+}
+
+func TestClientDryRun(t *testing.T) {
+	var requests int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer ts.Close()
+
+	buf := &bytes.Buffer{}
+
+	c := start(
+		"foo",
+		WithURL(ts.URL),
+		WithUserMapURL(ts.URL),
+		WithDryRun(buf, DryRunNDJSON),
+	)
+
+	assert.NoError(t, c.Enqueue(&Event{EventType: "user.created", UserID: "user-1", Timestamp: 1643367217}))
+	assert.NoError(t, c.Map(&UserMapping{UserID: "user-1", GlobalUserID: "global-1"}))
+	assert.NoError(t, c.Close())
+
+	assert.Equal(t, `{"api_key":"****","mapping":[{"user_id":"user-1","global_user_id":"global-1"}]}
+{"api_key":"****","events":[{"user_id":"user-1","event_type":"user.created","time":1643367217}]}
+`, buf.String())
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+}
+
+func TestClientDryRunWriteError(t *testing.T) {
+	var payloads []*Payload
+
+	c := New(
+		"foo",
+		WithDryRun(errWriter{}, DryRunNDJSON),
+		WithCallback(func(payload *Payload, err error) {
+			assert.NoError(t, err)
+
+			payloads = append(payloads, payload)
+		}),
+	)
+
+	assert.NoError(t, c.Enqueue(&Event{EventType: "user.created", UserID: "user-1", Timestamp: 1643367217}))
+	assert.NoError(t, c.Close())
+
+	// The payload is not retried.
+	assert.Len(t, payloads, 1)
+	assert.Equal(t, 1, payloads[0].Attempts)
+}
+
+func TestClientDebug(t *testing.T) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wg.Done()
+	}))
+	defer ts.Close()
+
+	buf := &bytes.Buffer{}
+
+	c := New(
+		"foo",
+		WithURL(ts.URL),
+		WithInterval(time.Millisecond*10),
+		WithDebug(buf, DryRunNDJSON),
+	)
+
+	assert.NoError(t, c.Enqueue(&Event{EventType: "user.created", UserID: "user-1", Timestamp: 1643367217}))
+
+	wg.Wait()
+
+	assert.NoError(t, c.Close())
+
+	assert.Equal(t, `{"api_key":"****","events":[{"user_id":"user-1","event_type":"user.created","time":1643367217}]}
+`, buf.String())
+}
+
+func TestMaskAPIKey(t *testing.T) {
+	assert.Equal(t, "****", maskAPIKey(""))
+	assert.Equal(t, "****", maskAPIKey("foo"))
+	assert.Equal(t, "****cdef", maskAPIKey("0123456789abcdef"))
+}
+
+func TestMaskPayload(t *testing.T) {
+	b, err := maskPayload([]byte(`{"events":[]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"events":[]}`, string(b))
+
+	b, err = maskPayload([]byte(`{"api_key":"0123456789abcdef","events":[]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"api_key":"****cdef","events":[]}`, string(b))
+
+	_, err = maskPayload([]byte(`{"api_key":1}`))
+	assert.Error(t, err)
+}
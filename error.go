@@ -4,7 +4,11 @@
 
 package amplitude
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
 
 var (
 	// ErrClosed message.
@@ -12,4 +16,38 @@ var (
 
 	// ErrBatchFailed message.
 	ErrBatchFailed = errors.New("request failed")
+
+	// ErrInvalidEvent message.
+	ErrInvalidEvent = errors.New("invalid event")
 )
+
+// APIError is returned by the REST API clients when Amplitude responds with an error status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%d: %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
+
+// DeliveryError is returned when a payload cannot be sent, Err is an
+// *ErrorResponse when Amplitude responds with a JSON error, an *APIError
+// for other error responses, or the network error.
+type DeliveryError struct {
+	Kind PayloadKind
+	Err  error
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrBatchFailed, e.Err)
+}
+
+// Unwrap returns ErrBatchFailed and Err.
+func (e *DeliveryError) Unwrap() []error {
+	return []error{ErrBatchFailed, e.Err}
+}
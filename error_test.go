@@ -0,0 +1,41 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	e := &APIError{
+		StatusCode: 404,
+	}
+
+	assert.Equal(t, "404: Not Found", e.Error())
+
+	e.Message = "user not found"
+
+	assert.Equal(t, "404: user not found", e.Error())
+}
+
+func TestDeliveryError(t *testing.T) {
+	e := &DeliveryError{
+		Kind: PayloadUserMap,
+		Err: &APIError{
+			StatusCode: 400,
+			Message:    "invalid mapping",
+		},
+	}
+
+	assert.Equal(t, "request failed: 400: invalid mapping", e.Error())
+	assert.ErrorIs(t, e, ErrBatchFailed)
+
+	var apiErr *APIError
+
+	assert.ErrorAs(t, e, &apiErr)
+	assert.Equal(t, 400, apiErr.StatusCode)
+}
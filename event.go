@@ -4,6 +4,8 @@
 
 package amplitude
 
+import "github.com/euskadi31/go-amplitude/internal/properties"
+
 // Event struct.
 // see: https://developers.amplitude.com/docs/http-api-v2
 type Event struct {
@@ -50,3 +52,33 @@ type Plan struct {
 	Source  string `json:"source,omitempty"`
 	Version string `json:"version,omitempty"`
 }
+
+// Clone returns a deep copy of the event, plugins use it to fan out events.
+func (e *Event) Clone() *Event {
+	clone := *e
+
+	clone.EventProperties = properties.Clone(e.EventProperties)
+	clone.UserProperties = properties.Clone(e.UserProperties)
+	clone.Groups = properties.Clone(e.Groups)
+
+	if e.Plan != nil {
+		plan := *e.Plan
+		clone.Plan = &plan
+	}
+
+	return &clone
+}
+
+// withProperty returns a copy of props with key set to value, the map of the
+// caller may be marshalled concurrently and is left untouched.
+func withProperty(props map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	clone := make(map[string]interface{}, len(props)+1)
+
+	for k, v := range props {
+		clone[k] = v
+	}
+
+	clone[key] = value
+
+	return clone
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventClone(t *testing.T) {
+	event := &Event{
+		EventType: "user.created",
+		EventProperties: map[string]interface{}{
+			"referrer": map[string]interface{}{
+				"source": "ads",
+			},
+			"tags": []interface{}{"a", map[string]interface{}{"b": 1}},
+			"ids":  []string{"1", "2"},
+		},
+		UserProperties: map[string]interface{}{
+			"plan": "premium",
+		},
+		Plan: &Plan{
+			Branch: "main",
+		},
+	}
+
+	clone := event.Clone()
+
+	assert.Equal(t, event, clone)
+
+	clone.EventProperties["referrer"].(map[string]interface{})["source"] = "email"
+	clone.EventProperties["tags"].([]interface{})[1].(map[string]interface{})["b"] = 2
+	clone.EventProperties["ids"].([]string)[0] = "3"
+	clone.UserProperties["plan"] = "free"
+	clone.Plan.Branch = "dev"
+
+	assert.Equal(t, "ads", event.EventProperties["referrer"].(map[string]interface{})["source"])
+	assert.Equal(t, 1, event.EventProperties["tags"].([]interface{})[1].(map[string]interface{})["b"])
+	assert.Equal(t, "1", event.EventProperties["ids"].([]string)[0])
+	assert.Equal(t, "premium", event.UserProperties["plan"])
+	assert.Equal(t, "main", event.Plan.Branch)
+
+	assert.Nil(t, (&Event{}).Clone().EventProperties)
+}
+
+func TestWithProperty(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"a": 1}, withProperty(nil, "a", 1))
+
+	props := map[string]interface{}{"a": 1}
+
+	assert.Equal(t, map[string]interface{}{"a": 1, "b": 2}, withProperty(props, "b", 2))
+	assert.Equal(t, map[string]interface{}{"a": 1}, props)
+}
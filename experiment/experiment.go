@@ -0,0 +1,139 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package experiment implements a client of the Amplitude Experiment evaluation API.
+// see: https://amplitude.com/docs/apis/experiment/experiment-evaluation-api
+package experiment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/euskadi31/go-amplitude/internal/api"
+	"github.com/euskadi31/go-amplitude/internal/cache"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	StandardEndpoint    = "https://api.lab.amplitude.com"
+	EUResidencyEndpoint = "https://api.lab.eu.amplitude.com"
+)
+
+// ErrMissingID is returned when a user has no user ID nor device ID.
+var ErrMissingID = errors.New("user id or device id is required")
+
+// Client of the remote evaluation API.
+type Client struct {
+	api       *api.Client
+	cacheTTL  time.Duration
+	cacheSize int
+	cache     *cache.Cache[map[string]*Variant]
+	tracker   amplitude.Client
+}
+
+// New remote evaluation client authenticated with the server deployment key.
+// Variants are cached for a minute by default, see WithCacheTTL.
+func New(deploymentKey string, opts ...Option) *Client {
+	c := &Client{
+		api:       api.New(StandardEndpoint),
+		cacheTTL:  time.Minute,
+		cacheSize: 10000,
+	}
+
+	c.api.Timeout = time.Second * 2
+	c.api.Authorize = api.APIKeyAuth(deploymentKey)
+
+	api.Apply(c, c.api, opts...)
+
+	if c.cacheTTL > 0 {
+		c.cache = cache.New[map[string]*Variant](c.cacheTTL, c.cacheSize)
+	}
+
+	return c
+}
+
+// Fetch returns the variants assigned to user by flag key, from the cache when possible.
+// Cached variants are shared and must not be modified.
+func (c *Client) Fetch(ctx context.Context, user *User) (map[string]*Variant, error) {
+	if user.UserID == "" && user.DeviceID == "" {
+		return nil, ErrMissingID
+	}
+
+	req, err := api.NewJSONRequest(http.MethodPost, "/v1/vardata", user)
+	if err != nil {
+		return nil, err
+	}
+
+	// The evaluation only reads the flags.
+	req.Idempotent = true
+
+	key := string(req.Body)
+
+	if c.cache != nil {
+		if variants, ok := c.cache.Get(key); ok {
+			return variants, nil
+		}
+	}
+
+	variants := map[string]*Variant{}
+
+	if err := c.api.JSON(ctx, req, &variants); err != nil {
+		return nil, err
+	}
+
+	if c.cache != nil {
+		c.cache.Set(key, variants)
+	}
+
+	return variants, nil
+}
+
+// Variant returns the variant of flagKey assigned to user, or nil when none is.
+// An exposure event is enqueued for the assigned variant, see WithExposureTracking.
+func (c *Client) Variant(ctx context.Context, user *User, flagKey string) (*Variant, error) {
+	variants, err := c.Fetch(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, ok := variants[flagKey]
+	if !ok || variant == nil {
+		return nil, nil //nolint:nilnil // no variant is assigned
+	}
+
+	c.track(ctx, user, flagKey, variant)
+
+	return variant, nil
+}
+
+func (c *Client) track(ctx context.Context, user *User, flagKey string, variant *Variant) {
+	// The default variants are not exposures, the user sees no experiment.
+	if c.tracker == nil || variant.IsDefault() {
+		return
+	}
+
+	if err := amplitude.EnqueueContext(ctx, c.tracker, ExposureEvent(user, flagKey, variant)); err != nil {
+		log.Error().Err(err).Str("flag_key", flagKey).Msg("enqueue exposure event failed")
+	}
+}
+
+// Invalidate removes the cached variants of user.
+func (c *Client) Invalidate(user *User) {
+	if c.cache == nil {
+		return
+	}
+
+	b, err := json.Marshal(user)
+	if err != nil {
+		log.Error().Err(err).Msg("json encode user failed")
+
+		return
+	}
+
+	c.cache.Delete(string(b))
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package experiment
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/euskadi31/go-amplitude/amplitudetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Api-Key deployment", r.Header.Get("Authorization"))
+
+		handler(w, r)
+	}))
+}
+
+func TestClientFetch(t *testing.T) {
+	calls := 0
+
+	ts := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/vardata", r.URL.Path)
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"user_id":"user-1","user_properties":{"plan":"premium"}}`, string(b))
+
+		w.Write([]byte(`{"new-player":{"key":"on","payload":{"color":"blue"}},"pricing":{"key":"treatment","value":"discount"}}`))
+	})
+	defer ts.Close()
+
+	c := New("deployment", WithURL(ts.URL), WithRetryInterval(time.Millisecond))
+
+	user := &User{
+		UserID: "user-1",
+		UserProperties: map[string]interface{}{
+			"plan": "premium",
+		},
+	}
+
+	variants, err := c.Fetch(context.Background(), user)
+	assert.NoError(t, err)
+	assert.Len(t, variants, 2)
+	assert.Equal(t, "on", variants["new-player"].Key)
+	assert.Equal(t, "discount", variants["pricing"].String())
+
+	_, err = c.Fetch(context.Background(), user)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	c.Invalidate(user)
+
+	_, err = c.Fetch(context.Background(), user)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClientFetchMissingID(t *testing.T) {
+	c := New("deployment")
+
+	_, err := c.Fetch(context.Background(), &User{})
+	assert.ErrorIs(t, err, ErrMissingID)
+}
+
+func TestClientFetchError(t *testing.T) {
+	ts := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	defer ts.Close()
+
+	c := New("deployment", WithURL(ts.URL))
+
+	_, err := c.Fetch(context.Background(), &User{UserID: "user-1"})
+	assert.Equal(t, &amplitude.APIError{StatusCode: http.StatusUnauthorized}, err)
+}
+
+func TestClientVariant(t *testing.T) {
+	ts := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"new-player":{"key":"on"},"dark-mode":{"key":"off","metadata":{"default":true}}}`))
+	})
+	defer ts.Close()
+
+	tracker := amplitudetest.NewClient()
+
+	c := New("deployment", WithURL(ts.URL), WithCacheTTL(0), WithExposureTracking(tracker))
+
+	user := &User{UserID: "user-1"}
+
+	variant, err := c.Variant(context.Background(), user, "new-player")
+	assert.NoError(t, err)
+	assert.Equal(t, &Variant{Key: "on"}, variant)
+
+	variant, err = c.Variant(context.Background(), user, "unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, variant)
+
+	// The default variants are returned without exposure.
+	variant, err = c.Variant(context.Background(), user, "dark-mode")
+	assert.NoError(t, err)
+	assert.True(t, variant.IsDefault())
+
+	assert.Len(t, tracker.Events(), 1)
+	assert.Equal(t, ExposureEventType, tracker.Events()[0].EventType)
+	assert.Equal(t, "new-player", tracker.Events()[0].EventProperties["flag_key"])
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package experimenttest implements a fake Amplitude Experiment server for tests.
+package experimenttest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/euskadi31/go-amplitude/experiment"
+	"github.com/euskadi31/go-amplitude/experiment/local"
+)
+
+// Server serves flag configurations to local evaluation clients and
+// evaluates them for remote evaluation clients.
+type Server struct {
+	*httptest.Server
+
+	mtx      sync.Mutex
+	flags    []*local.Flag
+	requests map[string]int
+}
+
+// NewServer starts a server serving flags, it must be closed by the caller.
+func NewServer(flags ...*local.Flag) *Server {
+	s := &Server{
+		flags:    flags,
+		requests: map[string]int{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+local.FlagsPath, s.handleFlags)
+	mux.HandleFunc("POST /v1/vardata", s.handleVardata)
+
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// SetFlags replaces the flags served.
+func (s *Server) SetFlags(flags ...*local.Flag) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.flags = flags
+}
+
+// Requests returns the number of requests received on path.
+func (s *Server) Requests(path string) int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.requests[path]
+}
+
+func (s *Server) serve(r *http.Request) []*local.Flag {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.requests[r.URL.Path]++
+
+	return s.flags
+}
+
+func (s *Server) handleFlags(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.serve(r))
+}
+
+func (s *Server) handleVardata(w http.ResponseWriter, r *http.Request) {
+	flags := s.serve(r)
+
+	user := &experiment.User{}
+
+	if err := json.NewDecoder(r.Body).Decode(user); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	byKey := make(map[string]*local.Flag, len(flags))
+
+	for _, flag := range flags {
+		byKey[flag.Key] = flag
+	}
+
+	variants, err := local.Evaluate(byKey, user)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	// Unassigned flags are omitted by remote evaluation.
+	for key, variant := range variants {
+		if variant.IsDefault() {
+			delete(variants, key)
+		}
+	}
+
+	writeJSON(w, variants)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// FullRollout returns a flag assigning variant to all the users.
+func FullRollout(key string, variant string) *local.Flag {
+	return &local.Flag{
+		Key: key,
+		Variants: map[string]*experiment.Variant{
+			variant: {Key: variant, Value: variant},
+			"off":   {Key: "off", Metadata: map[string]interface{}{"default": true}},
+		},
+		Segments: []*local.Segment{
+			{Variant: variant},
+		},
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package experimenttest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/euskadi31/go-amplitude/experiment"
+	"github.com/euskadi31/go-amplitude/experiment/local"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerRemoteEvaluation(t *testing.T) {
+	ts := NewServer(FullRollout("new-player", "on"), FullRollout("pricing", "off"))
+	defer ts.Close()
+
+	c := experiment.New("deployment", experiment.WithURL(ts.URL))
+
+	variants, err := c.Fetch(context.Background(), &experiment.User{UserID: "user-1"})
+	assert.NoError(t, err)
+
+	assert.Len(t, variants, 1)
+	assert.Equal(t, "on", variants["new-player"].Key)
+	assert.Equal(t, 1, ts.Requests("/v1/vardata"))
+}
+
+func TestServerFlags(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	ts.SetFlags(FullRollout("new-player", "on"))
+
+	c := local.New("deployment", local.WithURL(ts.URL))
+
+	assert.NoError(t, c.Refresh(context.Background()))
+	assert.Equal(t, FullRollout("new-player", "on"), c.Flags()["new-player"])
+	assert.Equal(t, 1, ts.Requests(local.FlagsPath))
+}
+
+func TestServerBadRequest(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/v1/vardata", "application/json", strings.NewReader("{"))
+	assert.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package local
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/euskadi31/go-amplitude/experiment"
+)
+
+// AssignmentEventType is the event type of assignment events.
+const AssignmentEventType = "[Experiment] Assignment"
+
+// AssignmentEvent returns the assignment event of user to variants, by flag key.
+func AssignmentEvent(user *experiment.User, variants map[string]*experiment.Variant) *amplitude.Event {
+	keys := make([]string, 0, len(variants))
+
+	for key := range variants {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	props := map[string]interface{}{}
+	set := map[string]interface{}{}
+	unset := map[string]interface{}{}
+
+	for _, key := range keys {
+		variant := variants[key]
+
+		props[key+".variant"] = variant.String()
+
+		if variant.IsDefault() {
+			unset["[Experiment] "+key] = "-"
+
+			continue
+		}
+
+		set["[Experiment] "+key] = variant.String()
+	}
+
+	userProps := map[string]interface{}{}
+
+	if len(set) > 0 {
+		userProps["$set"] = set
+	}
+
+	if len(unset) > 0 {
+		userProps["$unset"] = unset
+	}
+
+	return &amplitude.Event{
+		EventType:       AssignmentEventType,
+		UserID:          user.UserID,
+		DeviceID:        user.DeviceID,
+		EventProperties: props,
+		UserProperties:  userProps,
+	}
+}
+
+// assignmentKey identifies the assignment of user to variants.
+func assignmentKey(user *experiment.User, variants map[string]*experiment.Variant) string {
+	parts := make([]string, 0, len(variants))
+
+	for key, variant := range variants {
+		parts = append(parts, key+"="+variant.String())
+	}
+
+	sort.Strings(parts)
+
+	return user.UserID + " " + user.DeviceID + " " + strings.Join(parts, ",")
+}
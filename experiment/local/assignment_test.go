@@ -0,0 +1,42 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package local
+
+import (
+	"testing"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/euskadi31/go-amplitude/experiment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignmentEvent(t *testing.T) {
+	user := &experiment.User{UserID: "user-1", DeviceID: "device-1"}
+
+	variants := map[string]*experiment.Variant{
+		"new-player": {Key: "on"},
+		"pricing":    {Key: "off", Metadata: map[string]interface{}{"default": true}},
+	}
+
+	assert.Equal(t, &amplitude.Event{
+		EventType: AssignmentEventType,
+		UserID:    "user-1",
+		DeviceID:  "device-1",
+		EventProperties: map[string]interface{}{
+			"new-player.variant": "on",
+			"pricing.variant":    "off",
+		},
+		UserProperties: map[string]interface{}{
+			"$set": map[string]interface{}{
+				"[Experiment] new-player": "on",
+			},
+			"$unset": map[string]interface{}{
+				"[Experiment] pricing": "-",
+			},
+		},
+	}, AssignmentEvent(user, variants))
+
+	assert.Equal(t, "user-1 device-1 new-player=on,pricing=off", assignmentKey(user, variants))
+}
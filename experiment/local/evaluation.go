@@ -0,0 +1,612 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/euskadi31/go-amplitude/experiment"
+)
+
+// Operators of the conditions.
+const (
+	OpIs                    = "is"
+	OpIsNot                 = "is not"
+	OpContains              = "contains"
+	OpDoesNotContain        = "does not contain"
+	OpLessThan              = "less"
+	OpLessThanEquals        = "less or equal"
+	OpGreaterThan           = "greater"
+	OpGreaterThanEquals     = "greater or equal"
+	OpVersionLessThan       = "version less"
+	OpVersionLessThanEquals = "version less or equal"
+	OpVersionGreaterThan    = "version greater"
+	OpVersionGreaterEquals  = "version greater or equal"
+	OpSetIs                 = "set is"
+	OpSetIsNot              = "set is not"
+	OpSetContains           = "set contains"
+	OpSetDoesNotContain     = "set does not contain"
+	OpSetContainsAny        = "set contains any"
+	OpSetDoesNotContainAny  = "set does not contain any"
+	OpRegexMatch            = "regex match"
+	OpRegexDoesNotMatch     = "regex does not match"
+)
+
+// noneValue matches missing values.
+const noneValue = "(none)"
+
+// ErrDependencyCycle is returned by Evaluate when flags depend on each other.
+var ErrDependencyCycle = errors.New("flag dependency cycle")
+
+// Flag configuration.
+type Flag struct {
+	Key          string                         `json:"key"`
+	Variants     map[string]*experiment.Variant `json:"variants"`
+	Segments     []*Segment                     `json:"segments"`
+	Dependencies []string                       `json:"dependencies,omitempty"`
+	Metadata     map[string]interface{}         `json:"metadata,omitempty"`
+}
+
+// Segment of users assigned to a variant. The conditions are an OR of ANDs.
+type Segment struct {
+	Bucket     *Bucket                `json:"bucket,omitempty"`
+	Conditions [][]*Condition         `json:"conditions,omitempty"`
+	Variant    string                 `json:"variant,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Condition on the value selected in the evaluation target,
+// e.g. ["context", "user", "user_properties", "plan"].
+type Condition struct {
+	Selector []string `json:"selector"`
+	Op       string   `json:"op"`
+	Values   []string `json:"values"`
+}
+
+// Bucket allocates the users of a segment to variants by hashing the selected value.
+type Bucket struct {
+	Selector    []string      `json:"selector"`
+	Salt        string        `json:"salt"`
+	Allocations []*Allocation `json:"allocations"`
+}
+
+// Allocation of the hashes whose value modulo 100 is in Range.
+type Allocation struct {
+	Range         []uint64        `json:"range"`
+	Distributions []*Distribution `json:"distributions"`
+}
+
+// Distribution of the hashes whose value divided by 100 is in Range.
+type Distribution struct {
+	Variant string   `json:"variant"`
+	Range   []uint64 `json:"range"`
+}
+
+// Evaluate returns the variants of user for the flags of keys, or all the
+// flags when keys is empty. Dependencies are evaluated first and their
+// variants can be selected with ["result", "<flag key>", "key"].
+func Evaluate(flags map[string]*Flag, user *experiment.User, keys ...string) (map[string]*experiment.Variant, error) {
+	ordered, err := sortFlags(flags, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := newTarget(user)
+	if err != nil {
+		return nil, err
+	}
+
+	results := map[string]interface{}{}
+	target["result"] = results
+
+	requested := map[string]bool{}
+
+	for _, key := range keys {
+		requested[key] = true
+	}
+
+	variants := map[string]*experiment.Variant{}
+
+	for _, flag := range ordered {
+		variant := evaluateFlag(target, flag)
+		if variant == nil {
+			continue
+		}
+
+		if len(requested) == 0 || requested[flag.Key] {
+			variants[flag.Key] = variant
+		}
+
+		results[flag.Key] = map[string]interface{}{
+			"key":      variant.Key,
+			"value":    variant.Value,
+			"metadata": variant.Metadata,
+		}
+	}
+
+	return variants, nil
+}
+
+// sortFlags returns the flags of keys, or all the flags when keys is empty,
+// and their dependencies, dependencies first.
+func sortFlags(flags map[string]*Flag, keys []string) ([]*Flag, error) {
+	if len(keys) == 0 {
+		for key := range flags {
+			keys = append(keys, key)
+		}
+	}
+
+	var ordered []*Flag
+
+	// state is 1 while the dependencies of a flag are visited, then 2.
+	state := map[string]int{}
+
+	var visit func(key string) error
+
+	visit = func(key string) error {
+		switch state[key] {
+		case 1:
+			return fmt.Errorf("%w: %q", ErrDependencyCycle, key)
+		case 2:
+			return nil
+		}
+
+		flag, ok := flags[key]
+		if !ok {
+			return nil
+		}
+
+		state[key] = 1
+
+		for _, dependency := range flag.Dependencies {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+
+		state[key] = 2
+
+		ordered = append(ordered, flag)
+
+		return nil
+	}
+
+	for _, key := range sortedStrings(keys) {
+		if err := visit(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+func newTarget(user *experiment.User) (map[string]interface{}, error) {
+	b, err := json.Marshal(user)
+	if err != nil {
+		return nil, fmt.Errorf("json encode user failed: %w", err)
+	}
+
+	u := map[string]interface{}{}
+
+	if err := json.Unmarshal(b, &u); err != nil {
+		return nil, fmt.Errorf("json decode user failed: %w", err)
+	}
+
+	return map[string]interface{}{
+		"context": map[string]interface{}{
+			"user": u,
+		},
+	}, nil
+}
+
+func evaluateFlag(target map[string]interface{}, flag *Flag) *experiment.Variant {
+	for _, segment := range flag.Segments {
+		key, ok := evaluateSegment(target, segment)
+		if !ok {
+			continue
+		}
+
+		variant, ok := flag.Variants[key]
+		if !ok {
+			return nil
+		}
+
+		result := &experiment.Variant{
+			Key:      variant.Key,
+			Value:    variant.Value,
+			Payload:  variant.Payload,
+			Metadata: map[string]interface{}{},
+		}
+
+		if result.Key == "" {
+			result.Key = key
+		}
+
+		for _, metadata := range []map[string]interface{}{flag.Metadata, variant.Metadata, segment.Metadata} {
+			for k, v := range metadata {
+				result.Metadata[k] = v
+			}
+		}
+
+		return result
+	}
+
+	return nil
+}
+
+// evaluateSegment returns the variant key of the segment when the target matches its conditions.
+func evaluateSegment(target map[string]interface{}, segment *Segment) (string, bool) {
+	if len(segment.Conditions) > 0 && !matchConditions(target, segment.Conditions) {
+		return "", false
+	}
+
+	key := bucket(target, segment)
+
+	return key, key != ""
+}
+
+func matchConditions(target map[string]interface{}, conditions [][]*Condition) bool {
+	for _, and := range conditions {
+		match := true
+
+		for _, condition := range and {
+			if !matchCondition(target, condition) {
+				match = false
+
+				break
+			}
+		}
+
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
+
+func bucket(target map[string]interface{}, segment *Segment) string {
+	if segment.Bucket == nil {
+		return segment.Variant
+	}
+
+	value := coerceString(selectValue(target, segment.Bucket.Selector))
+	if value == "" {
+		return segment.Variant
+	}
+
+	hash := uint64(murmur3([]byte(segment.Bucket.Salt+"/"+value), 0))
+
+	allocationValue := hash % 100
+	distributionValue := hash / 100
+
+	for _, allocation := range segment.Bucket.Allocations {
+		if !inRange(allocation.Range, allocationValue) {
+			continue
+		}
+
+		for _, distribution := range allocation.Distributions {
+			if inRange(distribution.Range, distributionValue) {
+				return distribution.Variant
+			}
+		}
+	}
+
+	return segment.Variant
+}
+
+func inRange(r []uint64, value uint64) bool {
+	return len(r) == 2 && value >= r[0] && value < r[1]
+}
+
+func selectValue(target map[string]interface{}, selector []string) interface{} {
+	var value interface{} = target
+
+	for _, key := range selector {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		if value, ok = m[key]; !ok {
+			return nil
+		}
+	}
+
+	return value
+}
+
+func matchCondition(target map[string]interface{}, condition *Condition) bool {
+	value := selectValue(target, condition.Selector)
+
+	if value == nil || coerceString(value) == "" {
+		return matchNull(condition.Op, condition.Values)
+	}
+
+	if isSetOp(condition.Op) {
+		return matchSet(coerceStrings(value), condition.Op, condition.Values)
+	}
+
+	return matchString(coerceString(value), condition.Op, condition.Values)
+}
+
+func matchNull(op string, values []string) bool {
+	containsNone := false
+
+	for _, value := range values {
+		if value == noneValue {
+			containsNone = true
+		}
+	}
+
+	switch op {
+	case OpIsNot, OpDoesNotContain, OpSetIsNot, OpSetDoesNotContain, OpSetDoesNotContainAny:
+		return !containsNone
+	case OpRegexMatch, OpRegexDoesNotMatch:
+		return false
+	}
+
+	return containsNone
+}
+
+func isSetOp(op string) bool {
+	switch op {
+	case OpSetIs, OpSetIsNot, OpSetContains, OpSetDoesNotContain, OpSetContainsAny, OpSetDoesNotContainAny:
+		return true
+	}
+
+	return false
+}
+
+func matchSet(value []string, op string, values []string) bool {
+	set := map[string]bool{}
+
+	for _, v := range value {
+		set[v] = true
+	}
+
+	contains := 0
+
+	for _, v := range values {
+		if set[v] {
+			contains++
+		}
+	}
+
+	switch op {
+	case OpSetIs:
+		return contains == len(values) && len(set) == len(values)
+	case OpSetIsNot:
+		return contains != len(values) || len(set) != len(values)
+	case OpSetContains:
+		return contains == len(values)
+	case OpSetDoesNotContain:
+		return contains != len(values)
+	case OpSetContainsAny:
+		return contains > 0
+	case OpSetDoesNotContainAny:
+		return contains == 0
+	}
+
+	return false
+}
+
+//nolint:gocyclo,cyclop // one case per operator
+func matchString(value string, op string, values []string) bool {
+	switch op {
+	case OpIs:
+		return matchIs(value, values)
+	case OpIsNot:
+		return !matchIs(value, values)
+	case OpContains:
+		return matchContains(value, values)
+	case OpDoesNotContain:
+		return !matchContains(value, values)
+	case OpLessThan, OpLessThanEquals, OpGreaterThan, OpGreaterThanEquals:
+		return matchAny(values, func(v string) bool {
+			return compare(op, compareValues(value, v))
+		})
+	case OpVersionLessThan, OpVersionLessThanEquals, OpVersionGreaterThan, OpVersionGreaterEquals:
+		return matchAny(values, func(v string) bool {
+			c, ok := compareVersions(value, v)
+
+			return ok && compare(op, c)
+		})
+	case OpRegexMatch:
+		return matchRegex(value, values)
+	case OpRegexDoesNotMatch:
+		return !matchRegex(value, values)
+	}
+
+	return false
+}
+
+func matchAny(values []string, fn func(v string) bool) bool {
+	for _, v := range values {
+		if fn(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchIs(value string, values []string) bool {
+	return matchAny(values, func(v string) bool {
+		if isBool(v) && isBool(value) {
+			return strings.EqualFold(value, v)
+		}
+
+		return value == v
+	})
+}
+
+func isBool(s string) bool {
+	return strings.EqualFold(s, "true") || strings.EqualFold(s, "false")
+}
+
+func matchContains(value string, values []string) bool {
+	value = strings.ToLower(value)
+
+	return matchAny(values, func(v string) bool {
+		return strings.Contains(value, strings.ToLower(v))
+	})
+}
+
+func matchRegex(value string, values []string) bool {
+	return matchAny(values, func(v string) bool {
+		re, err := regexp.Compile(v)
+
+		return err == nil && re.MatchString(value)
+	})
+}
+
+// compare returns whether the result of a comparison satisfies op.
+func compare(op string, c int) bool {
+	switch op {
+	case OpLessThan, OpVersionLessThan:
+		return c < 0
+	case OpLessThanEquals, OpVersionLessThanEquals:
+		return c <= 0
+	case OpGreaterThan, OpVersionGreaterThan:
+		return c > 0
+	case OpGreaterThanEquals, OpVersionGreaterEquals:
+		return c >= 0
+	}
+
+	return false
+}
+
+// compareValues compares numbers numerically and other values lexically.
+func compareValues(a string, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+
+	return 0
+}
+
+// compareVersions compares "major.minor.patch-prerelease" versions, prereleases are lower than releases.
+func compareVersions(a string, b string) (int, bool) {
+	va, ok := parseVersion(a)
+	if !ok {
+		return 0, false
+	}
+
+	vb, ok := parseVersion(b)
+	if !ok {
+		return 0, false
+	}
+
+	for i := 0; i < 3; i++ {
+		switch {
+		case va.numbers[i] < vb.numbers[i]:
+			return -1, true
+		case va.numbers[i] > vb.numbers[i]:
+			return 1, true
+		}
+	}
+
+	switch {
+	case va.prerelease == vb.prerelease:
+		return 0, true
+	case va.prerelease == "":
+		return 1, true
+	case vb.prerelease == "":
+		return -1, true
+	}
+
+	return strings.Compare(va.prerelease, vb.prerelease), true
+}
+
+type version struct {
+	numbers    [3]int
+	prerelease string
+}
+
+func parseVersion(s string) (version, bool) {
+	var v version
+
+	s, v.prerelease, _ = strings.Cut(strings.TrimSpace(s), "-")
+
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return v, false
+	}
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+
+		v.numbers[i] = n
+	}
+
+	return v, true
+}
+
+// coerceString returns the string representation of a value, JSON for objects and arrays.
+func coerceString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+
+		return string(b)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// coerceStrings returns the values of an array, or of a JSON encoded array.
+func coerceStrings(value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		s := coerceString(value)
+
+		if err := json.Unmarshal([]byte(s), &items); err != nil {
+			return []string{s}
+		}
+	}
+
+	values := make([]string, 0, len(items))
+
+	for _, item := range items {
+		values = append(values, coerceString(item))
+	}
+
+	return values
+}
+
+func sortedStrings(values []string) []string {
+	sorted := append([]string(nil), values...)
+
+	sort.Strings(sorted)
+
+	return sorted
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/euskadi31/go-amplitude/experiment"
+	"github.com/stretchr/testify/assert"
+)
+
+var userPlan = []string{"context", "user", "user_properties", "plan"}
+
+func TestMatchCondition(t *testing.T) {
+	target, err := newTarget(&experiment.User{
+		UserID:   "user-1",
+		Platform: "iOS",
+		Version:  "1.10.0",
+		UserProperties: map[string]interface{}{
+			"plan":     "premium",
+			"age":      42,
+			"verified": true,
+			"tags":     []interface{}{"a", "b"},
+		},
+	})
+	assert.NoError(t, err)
+
+	prop := func(name string) []string {
+		return []string{"context", "user", "user_properties", name}
+	}
+
+	for _, tt := range []struct {
+		selector []string
+		op       string
+		values   []string
+		match    bool
+	}{
+		{userPlan, OpIs, []string{"free", "premium"}, true},
+		{userPlan, OpIs, []string{"free"}, false},
+		{userPlan, OpIsNot, []string{"free"}, true},
+		{userPlan, OpContains, []string{"PREM"}, true},
+		{userPlan, OpDoesNotContain, []string{"prem"}, false},
+		{userPlan, OpRegexMatch, []string{"^pre"}, true},
+		{userPlan, OpRegexDoesNotMatch, []string{"^pre"}, false},
+		{prop("verified"), OpIs, []string{"TRUE"}, true},
+		{prop("age"), OpGreaterThan, []string{"9"}, true},
+		{prop("age"), OpLessThanEquals, []string{"42"}, true},
+		{prop("age"), OpLessThan, []string{"42"}, false},
+		{[]string{"context", "user", "version"}, OpVersionGreaterThan, []string{"1.9.3"}, true},
+		{[]string{"context", "user", "version"}, OpVersionLessThan, []string{"1.10.0-beta"}, false},
+		{[]string{"context", "user", "version"}, OpVersionGreaterEquals, []string{"invalid"}, false},
+		{prop("tags"), OpSetIs, []string{"b", "a"}, true},
+		{prop("tags"), OpSetIsNot, []string{"a"}, true},
+		{prop("tags"), OpSetContains, []string{"a"}, true},
+		{prop("tags"), OpSetDoesNotContain, []string{"c"}, true},
+		{prop("tags"), OpSetContainsAny, []string{"c", "b"}, true},
+		{prop("tags"), OpSetDoesNotContainAny, []string{"c", "b"}, false},
+		{prop("missing"), OpIs, []string{"(none)"}, true},
+		{prop("missing"), OpIs, []string{"premium"}, false},
+		{prop("missing"), OpIsNot, []string{"premium"}, true},
+		{prop("missing"), OpRegexMatch, []string{".*"}, false},
+		{prop("plan"), "unknown", []string{"premium"}, false},
+	} {
+		assert.Equal(t, tt.match, matchCondition(target, &Condition{
+			Selector: tt.selector,
+			Op:       tt.op,
+			Values:   tt.values,
+		}), fmt.Sprintf("%v %s %v", tt.selector, tt.op, tt.values))
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	c, ok := compareVersions("1.2", "1.2.0")
+	assert.True(t, ok)
+	assert.Equal(t, 0, c)
+
+	c, ok = compareVersions("1.2.0-alpha", "1.2.0-beta")
+	assert.True(t, ok)
+	assert.Equal(t, -1, c)
+
+	_, ok = compareVersions("1", "1.2.0")
+	assert.False(t, ok)
+}
+
+func TestCoerceStrings(t *testing.T) {
+	assert.Equal(t, []string{"a", "1"}, coerceStrings(`["a",1]`))
+	assert.Equal(t, []string{"a"}, coerceStrings("a"))
+	assert.Equal(t, []string{`{"a":1}`}, coerceStrings(map[string]interface{}{"a": 1}))
+}
+
+func rolloutFlag() *Flag {
+	flag := &Flag{}
+
+	if err := json.Unmarshal([]byte(`{
+		"key": "new-player",
+		"metadata": {"flagType": "experiment", "experimentKey": "exp-1"},
+		"variants": {
+			"off": {"key": "off", "metadata": {"default": true}},
+			"control": {"key": "control", "value": "control"},
+			"treatment": {"key": "treatment", "value": "treatment", "payload": {"color": "blue"}}
+		},
+		"segments": [
+			{
+				"conditions": [[{"selector": ["context", "user", "user_properties", "plan"], "op": "is", "values": ["internal"]}]],
+				"variant": "treatment",
+				"metadata": {"segmentName": "internal"}
+			},
+			{
+				"bucket": {
+					"selector": ["context", "user", "device_id"],
+					"salt": "KrA0vmFE",
+					"allocations": [{
+						"range": [0, 50],
+						"distributions": [
+							{"variant": "control", "range": [0, 21474837]},
+							{"variant": "treatment", "range": [21474837, 42949673]}
+						]
+					}]
+				},
+				"variant": "off"
+			}
+		]
+	}`), flag); err != nil {
+		panic(err)
+	}
+
+	return flag
+}
+
+func TestEvaluateSegments(t *testing.T) {
+	flags := map[string]*Flag{"new-player": rolloutFlag()}
+
+	variants, err := Evaluate(flags, &experiment.User{
+		DeviceID: "device-1",
+		UserProperties: map[string]interface{}{
+			"plan": "internal",
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, &experiment.Variant{
+		Key:     "treatment",
+		Value:   "treatment",
+		Payload: json.RawMessage(`{"color": "blue"}`),
+		Metadata: map[string]interface{}{
+			"flagType":      "experiment",
+			"experimentKey": "exp-1",
+			"segmentName":   "internal",
+		},
+	}, variants["new-player"])
+
+	// Users without device ID are not bucketed.
+	variants, err = Evaluate(flags, &experiment.User{UserID: "user-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "off", variants["new-player"].Key)
+}
+
+func TestEvaluateBucketing(t *testing.T) {
+	flags := map[string]*Flag{"new-player": rolloutFlag()}
+
+	counts := map[string]int{}
+
+	for i := 0; i < 10000; i++ {
+		user := &experiment.User{DeviceID: fmt.Sprintf("device-%d", i)}
+
+		variants, err := Evaluate(flags, user)
+		assert.NoError(t, err)
+
+		counts[variants["new-player"].Key]++
+
+		// The assignment is deterministic.
+		again, err := Evaluate(flags, user)
+		assert.NoError(t, err)
+		assert.Equal(t, variants["new-player"].Key, again["new-player"].Key)
+	}
+
+	// Half of the users are allocated, then split evenly.
+	assert.InDelta(t, 5000, counts["off"], 250)
+	assert.InDelta(t, 2500, counts["control"], 250)
+	assert.InDelta(t, 2500, counts["treatment"], 250)
+}
+
+func TestEvaluateDependencies(t *testing.T) {
+	flags := map[string]*Flag{
+		"parent": {
+			Key:      "parent",
+			Variants: map[string]*experiment.Variant{"on": {Key: "on"}},
+			Segments: []*Segment{{Variant: "on"}},
+		},
+		"child": {
+			Key:          "child",
+			Dependencies: []string{"parent"},
+			Variants:     map[string]*experiment.Variant{"on": {Key: "on"}},
+			Segments: []*Segment{{
+				Conditions: [][]*Condition{{{Selector: []string{"result", "parent", "key"}, Op: OpIs, Values: []string{"on"}}}},
+				Variant:    "on",
+			}},
+		},
+	}
+
+	variants, err := Evaluate(flags, &experiment.User{UserID: "user-1"}, "child")
+	assert.NoError(t, err)
+	assert.Len(t, variants, 1)
+	assert.Equal(t, "on", variants["child"].Key)
+
+	flags["parent"].Dependencies = []string{"child"}
+
+	_, err = Evaluate(flags, &experiment.User{UserID: "user-1"})
+	assert.ErrorIs(t, err, ErrDependencyCycle)
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package local implements the local evaluation of Amplitude Experiment flags.
+// see: https://amplitude.com/docs/sdks/experiment-sdks/experiment-go
+package local
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/euskadi31/go-amplitude/experiment"
+	"github.com/euskadi31/go-amplitude/internal/api"
+	"github.com/euskadi31/go-amplitude/internal/cache"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	StandardEndpoint    = "https://flag.lab.amplitude.com"
+	EUResidencyEndpoint = "https://flag.lab.eu.amplitude.com"
+)
+
+// FlagsPath is the path of the flag configurations API.
+const FlagsPath = "/sdk/v2/flags"
+
+// Client evaluates the flags locally, their configurations are polled in the background once started.
+type Client struct {
+	api          *api.Client
+	pollInterval time.Duration
+	tracker      amplitude.Client
+	assignments  *cache.Cache[bool]
+	mtx          sync.RWMutex
+	flags        map[string]*Flag
+	quitCh       chan struct{}
+	shutdownCh   chan struct{}
+	startOnce    sync.Once
+	closeOnce    sync.Once
+}
+
+// New local evaluation client authenticated with the server deployment key.
+func New(deploymentKey string, opts ...Option) *Client {
+	c := &Client{
+		api:          api.New(StandardEndpoint),
+		pollInterval: time.Second * 30,
+		assignments:  cache.New[bool](time.Hour*24, 65536),
+		flags:        map[string]*Flag{},
+		quitCh:       make(chan struct{}),
+		shutdownCh:   make(chan struct{}),
+	}
+
+	c.api.Timeout = time.Second * 10
+	c.api.Authorize = api.APIKeyAuth(deploymentKey)
+
+	api.Apply(c, c.api, opts...)
+
+	return c
+}
+
+// Start fetches the flag configurations then polls them until Close is called.
+func (c *Client) Start(ctx context.Context) error {
+	if err := c.Refresh(ctx); err != nil {
+		return err
+	}
+
+	c.startOnce.Do(func() {
+		go c.poll()
+	})
+
+	return nil
+}
+
+func (c *Client) poll() {
+	defer close(c.shutdownCh)
+
+	tick := time.NewTicker(c.pollInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-tick.C:
+			if err := c.Refresh(context.Background()); err != nil {
+				log.Error().Err(err).Msg("Amplitude Experiment refresh flags failed")
+			}
+		case <-c.quitCh:
+			return
+		}
+	}
+}
+
+// Close stops polling the flag configurations.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.quitCh)
+
+		// shutdownCh is never closed when the client was not started.
+		c.startOnce.Do(func() {
+			close(c.shutdownCh)
+		})
+	})
+
+	<-c.shutdownCh
+
+	return nil
+}
+
+// Refresh fetches the flag configurations.
+func (c *Client) Refresh(ctx context.Context) error {
+	var flags []*Flag
+
+	if err := c.api.JSON(ctx, &api.Request{Method: http.MethodGet, Path: FlagsPath}, &flags); err != nil {
+		return err
+	}
+
+	byKey := make(map[string]*Flag, len(flags))
+
+	for _, flag := range flags {
+		byKey[flag.Key] = flag
+	}
+
+	c.mtx.Lock()
+	c.flags = byKey
+	c.mtx.Unlock()
+
+	return nil
+}
+
+// Flags returns the flag configurations by key.
+// They are shared and must not be modified.
+func (c *Client) Flags() map[string]*Flag {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.flags
+}
+
+// Evaluate returns the variants assigned to user by flag key, for the flags
+// of keys or all the flags when keys is empty. Default variants are omitted.
+// An assignment event is enqueued, see WithAssignmentTracking.
+func (c *Client) Evaluate(ctx context.Context, user *experiment.User, keys ...string) (map[string]*experiment.Variant, error) {
+	variants, err := Evaluate(c.Flags(), user, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.track(ctx, user, variants)
+
+	for key, variant := range variants {
+		if variant.IsDefault() {
+			delete(variants, key)
+		}
+	}
+
+	return variants, nil
+}
+
+// Variant returns the variant of flagKey assigned to user, or nil when none is.
+func (c *Client) Variant(ctx context.Context, user *experiment.User, flagKey string) (*experiment.Variant, error) {
+	variants, err := c.Evaluate(ctx, user, flagKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return variants[flagKey], nil
+}
+
+func (c *Client) track(ctx context.Context, user *experiment.User, variants map[string]*experiment.Variant) {
+	if c.tracker == nil || len(variants) == 0 {
+		return
+	}
+
+	key := assignmentKey(user, variants)
+
+	if _, ok := c.assignments.Get(key); ok {
+		return
+	}
+
+	c.assignments.Set(key, true)
+
+	if err := amplitude.EnqueueContext(ctx, c.tracker, AssignmentEvent(user, variants)); err != nil {
+		log.Error().Err(err).Msg("enqueue assignment event failed")
+	}
+}
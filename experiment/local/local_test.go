@@ -0,0 +1,96 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package local_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/euskadi31/go-amplitude/amplitudetest"
+	"github.com/euskadi31/go-amplitude/experiment"
+	"github.com/euskadi31/go-amplitude/experiment/experimenttest"
+	"github.com/euskadi31/go-amplitude/experiment/local"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientEvaluate(t *testing.T) {
+	ts := experimenttest.NewServer(
+		experimenttest.FullRollout("new-player", "on"),
+		experimenttest.FullRollout("pricing", "off"),
+	)
+	defer ts.Close()
+
+	tracker := amplitudetest.NewClient()
+
+	c := local.New("deployment", local.WithURL(ts.URL), local.WithAssignmentTracking(tracker))
+	defer c.Close()
+
+	assert.NoError(t, c.Start(context.Background()))
+
+	user := &experiment.User{UserID: "user-1"}
+
+	variants, err := c.Evaluate(context.Background(), user)
+	assert.NoError(t, err)
+
+	// The default variant of pricing is omitted.
+	assert.Len(t, variants, 1)
+	assert.Equal(t, "on", variants["new-player"].Key)
+
+	variant, err := c.Variant(context.Background(), user, "new-player")
+	assert.NoError(t, err)
+	assert.Equal(t, "on", variant.Key)
+
+	variant, err = c.Variant(context.Background(), user, "unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, variant)
+
+	_, err = c.Evaluate(context.Background(), user)
+	assert.NoError(t, err)
+
+	// The same assignment is tracked once.
+	assert.Len(t, tracker.Events(), 2)
+	assert.Equal(t, local.AssignmentEventType, tracker.Events()[0].EventType)
+	assert.Equal(t, "off", tracker.Events()[0].EventProperties["pricing.variant"])
+	assert.Equal(t, "on", tracker.Events()[1].EventProperties["new-player.variant"])
+}
+
+func TestClientPoll(t *testing.T) {
+	ts := experimenttest.NewServer()
+	defer ts.Close()
+
+	c := local.New("deployment", local.WithURL(ts.URL), local.WithPollInterval(time.Millisecond*10))
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.Empty(t, c.Flags())
+
+	ts.SetFlags(experimenttest.FullRollout("new-player", "on"))
+
+	assert.Eventually(t, func() bool {
+		return len(c.Flags()) == 1
+	}, time.Second, time.Millisecond*10)
+
+	assert.NoError(t, c.Close())
+	assert.NoError(t, c.Close())
+
+	assert.GreaterOrEqual(t, ts.Requests(local.FlagsPath), 2)
+}
+
+func TestClientStartError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Api-Key deployment", r.Header.Get("Authorization"))
+
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	c := local.New("deployment", local.WithURL(ts.URL))
+
+	assert.Equal(t, &amplitude.APIError{StatusCode: http.StatusUnauthorized}, c.Start(context.Background()))
+	assert.NoError(t, c.Close())
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package local
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMurmur3(t *testing.T) {
+	for _, tt := range []struct {
+		data string
+		seed uint32
+		hash uint32
+	}{
+		{"", 0, 0},
+		{"", 1, 0x514e28b7},
+		{"a", 0, 0x3c2569b2},
+		{"abc", 0, 0xb3dd93fa},
+		{"abcd", 0, 0x43ed676a},
+		{"Hello, world!", 0, 0xc0363e43},
+		{"The quick brown fox jumps over the lazy dog", 0, 0x2e4ff723},
+	} {
+		assert.Equal(t, tt.hash, murmur3([]byte(tt.data), tt.seed), tt.data)
+	}
+}
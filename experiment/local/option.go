@@ -0,0 +1,38 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package local
+
+import (
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/euskadi31/go-amplitude/internal/api"
+)
+
+// Option configures a Client.
+type Option = api.Option[Client]
+
+// The options shared by the REST API clients.
+var (
+	WithURL           = api.WithURL[Client]
+	WithTimeout       = api.WithTimeout[Client]
+	WithMaxRetry      = api.WithMaxRetry[Client]
+	WithRetryInterval = api.WithRetryInterval[Client]
+	WithHTTPClient    = api.WithHTTPClient[Client]
+)
+
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *Client, _ *api.Client) {
+		c.pollInterval = interval
+	}
+}
+
+// WithAssignmentTracking enqueues an assignment event into client for each user evaluated,
+// at most once a day for the same variants.
+func WithAssignmentTracking(client amplitude.Client) Option {
+	return func(c *Client, _ *api.Client) {
+		c.tracker = client
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package local
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/amplitudetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions(t *testing.T) {
+	hc := &http.Client{}
+	tracker := amplitudetest.NewClient()
+
+	c := New(
+		"deployment",
+		WithURL(EUResidencyEndpoint),
+		WithTimeout(time.Second*2),
+		WithMaxRetry(5),
+		WithRetryInterval(time.Second*3),
+		WithHTTPClient(hc),
+		WithPollInterval(time.Second*4),
+		WithAssignmentTracking(tracker),
+	)
+
+	assert.Equal(t, EUResidencyEndpoint, c.api.Endpoint)
+	assert.Equal(t, time.Second*2, c.api.Timeout)
+	assert.Equal(t, 5, c.api.MaxRetry)
+	assert.Equal(t, time.Second*3, c.api.RetryInterval)
+	assert.Equal(t, hc, c.api.HTTPClient)
+	assert.Equal(t, time.Second*4, c.pollInterval)
+	assert.Equal(t, tracker, c.tracker)
+}
+
+func TestNewWithTimeout(t *testing.T) {
+	c := New("deployment", WithTimeout(time.Second*2))
+
+	assert.Equal(t, time.Second*2, c.api.HTTPClient.Timeout)
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package experiment
+
+import (
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/euskadi31/go-amplitude/internal/api"
+)
+
+// Option configures a Client.
+type Option = api.Option[Client]
+
+// The options shared by the REST API clients.
+var (
+	WithURL           = api.WithURL[Client]
+	WithTimeout       = api.WithTimeout[Client]
+	WithMaxRetry      = api.WithMaxRetry[Client]
+	WithRetryInterval = api.WithRetryInterval[Client]
+	WithHTTPClient    = api.WithHTTPClient[Client]
+)
+
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(c *Client, _ *api.Client) {
+		c.cacheTTL = ttl
+	}
+}
+
+func WithCacheSize(size int) Option {
+	return func(c *Client, _ *api.Client) {
+		c.cacheSize = size
+	}
+}
+
+// WithExposureTracking enqueues an exposure event into client for each variant returned by Variant.
+func WithExposureTracking(client amplitude.Client) Option {
+	return func(c *Client, _ *api.Client) {
+		c.tracker = client
+	}
+}
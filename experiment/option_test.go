@@ -0,0 +1,52 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package experiment
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/amplitudetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions(t *testing.T) {
+	hc := &http.Client{}
+
+	c := New(
+		"secret",
+		WithURL(EUResidencyEndpoint),
+		WithTimeout(time.Second*2),
+		WithMaxRetry(5),
+		WithRetryInterval(time.Second*3),
+		WithHTTPClient(hc),
+		WithCacheTTL(time.Second*4),
+		WithCacheSize(10),
+	)
+
+	assert.Equal(t, EUResidencyEndpoint, c.api.Endpoint)
+	assert.Equal(t, time.Second*2, c.api.Timeout)
+	assert.Equal(t, 5, c.api.MaxRetry)
+	assert.Equal(t, time.Second*3, c.api.RetryInterval)
+	assert.Equal(t, hc, c.api.HTTPClient)
+	assert.Equal(t, time.Second*4, c.cacheTTL)
+	assert.Equal(t, 10, c.cacheSize)
+	assert.NotNil(t, c.cache)
+}
+
+func TestNewWithTimeout(t *testing.T) {
+	c := New("secret", WithTimeout(time.Second*2))
+
+	assert.Equal(t, time.Second*2, c.api.HTTPClient.Timeout)
+}
+
+func TestWithExposureTracking(t *testing.T) {
+	tracker := amplitudetest.NewClient()
+
+	c := New("secret", WithExposureTracking(tracker))
+
+	assert.Equal(t, tracker, c.tracker)
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package experiment
+
+import (
+	"github.com/euskadi31/go-amplitude"
+)
+
+// User evaluated by Amplitude Experiment.
+type User struct {
+	UserID             string                 `json:"user_id,omitempty"`
+	DeviceID           string                 `json:"device_id,omitempty"`
+	Country            string                 `json:"country,omitempty"`
+	Region             string                 `json:"region,omitempty"`
+	City               string                 `json:"city,omitempty"`
+	DMA                string                 `json:"dma,omitempty"`
+	Language           string                 `json:"language,omitempty"`
+	Platform           string                 `json:"platform,omitempty"`
+	Version            string                 `json:"version,omitempty"`
+	OS                 string                 `json:"os,omitempty"`
+	DeviceManufacturer string                 `json:"device_manufacturer,omitempty"`
+	DeviceBrand        string                 `json:"device_brand,omitempty"`
+	DeviceModel        string                 `json:"device_model,omitempty"`
+	Carrier            string                 `json:"carrier,omitempty"`
+	UserProperties     map[string]interface{} `json:"user_properties,omitempty"`
+	Groups             map[string]interface{} `json:"groups,omitempty"`
+}
+
+// UserFromEvent returns the user of event.
+func UserFromEvent(event *amplitude.Event) *User {
+	return &User{
+		UserID:             event.UserID,
+		DeviceID:           event.DeviceID,
+		Country:            event.Country,
+		Region:             event.Region,
+		City:               event.City,
+		DMA:                event.DMA,
+		Language:           event.Language,
+		Platform:           event.Platform,
+		Version:            event.AppVersion,
+		OS:                 event.OSName,
+		DeviceManufacturer: event.DeviceManufacturer,
+		DeviceBrand:        event.DeviceBrand,
+		DeviceModel:        event.DeviceModel,
+		Carrier:            event.Carrier,
+		UserProperties:     event.UserProperties,
+		Groups:             event.Groups,
+	}
+}
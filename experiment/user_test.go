@@ -0,0 +1,41 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package experiment
+
+import (
+	"testing"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserFromEvent(t *testing.T) {
+	user := UserFromEvent(&amplitude.Event{
+		EventType:  "Song Played",
+		UserID:     "user-1",
+		DeviceID:   "device-1",
+		AppVersion: "1.4.0",
+		Platform:   "ios",
+		OSName:     "iOS",
+		Country:    "France",
+		Language:   "fr-FR",
+		UserProperties: map[string]interface{}{
+			"plan": "premium",
+		},
+	})
+
+	assert.Equal(t, &User{
+		UserID:   "user-1",
+		DeviceID: "device-1",
+		Version:  "1.4.0",
+		Platform: "ios",
+		OS:       "iOS",
+		Country:  "France",
+		Language: "fr-FR",
+		UserProperties: map[string]interface{}{
+			"plan": "premium",
+		},
+	}, user)
+}
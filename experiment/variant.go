@@ -0,0 +1,76 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package experiment
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/euskadi31/go-amplitude"
+)
+
+// ExposureEventType is the event type of exposure events.
+const ExposureEventType = "$exposure"
+
+// Variant of a flag assigned to a user.
+type Variant struct {
+	Key      string                 `json:"key"`
+	Value    string                 `json:"value,omitempty"`
+	Payload  json.RawMessage        `json:"payload,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// String returns the value of the variant, or its key.
+func (v *Variant) String() string {
+	if v.Value != "" {
+		return v.Value
+	}
+
+	return v.Key
+}
+
+// IsDefault returns whether the variant is the default variant of its flag, i.e. no variant is assigned.
+func (v *Variant) IsDefault() bool {
+	d, _ := v.Metadata["default"].(bool)
+
+	return d
+}
+
+// DecodePayload decodes the JSON payload of the variant into out.
+func (v *Variant) DecodePayload(out interface{}) error {
+	if len(v.Payload) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(v.Payload, out); err != nil {
+		return fmt.Errorf("json decode variant payload failed: %w", err)
+	}
+
+	return nil
+}
+
+// ExposureEvent returns the exposure event of user to the variant of flagKey.
+func ExposureEvent(user *User, flagKey string, variant *Variant) *amplitude.Event {
+	props := map[string]interface{}{
+		"flag_key": flagKey,
+		"variant":  variant.String(),
+	}
+
+	if experimentKey, ok := variant.Metadata["experimentKey"].(string); ok && experimentKey != "" {
+		props["experiment_key"] = experimentKey
+	}
+
+	return &amplitude.Event{
+		EventType:       ExposureEventType,
+		UserID:          user.UserID,
+		DeviceID:        user.DeviceID,
+		EventProperties: props,
+		UserProperties: map[string]interface{}{
+			"$set": map[string]interface{}{
+				"[Experiment] " + flagKey: variant.String(),
+			},
+		},
+	}
+}
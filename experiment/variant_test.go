@@ -0,0 +1,67 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package experiment
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariantString(t *testing.T) {
+	assert.Equal(t, "on", (&Variant{Key: "on"}).String())
+	assert.Equal(t, "blue", (&Variant{Key: "treatment", Value: "blue"}).String())
+}
+
+func TestVariantIsDefault(t *testing.T) {
+	assert.False(t, (&Variant{Key: "on"}).IsDefault())
+	assert.False(t, (&Variant{Key: "on", Metadata: map[string]interface{}{"default": false}}).IsDefault())
+	assert.True(t, (&Variant{Key: "off", Metadata: map[string]interface{}{"default": true}}).IsDefault())
+}
+
+func TestVariantDecodePayload(t *testing.T) {
+	payload := struct {
+		Color string `json:"color"`
+	}{}
+
+	assert.NoError(t, (&Variant{Key: "on"}).DecodePayload(&payload))
+	assert.Empty(t, payload.Color)
+
+	v := &Variant{Key: "on", Payload: json.RawMessage(`{"color":"blue"}`)}
+
+	assert.NoError(t, v.DecodePayload(&payload))
+	assert.Equal(t, "blue", payload.Color)
+
+	var n int
+
+	assert.Error(t, v.DecodePayload(&n))
+}
+
+func TestExposureEvent(t *testing.T) {
+	event := ExposureEvent(&User{UserID: "user-1", DeviceID: "device-1"}, "new-player", &Variant{
+		Key: "on",
+		Metadata: map[string]interface{}{
+			"experimentKey": "exp-1",
+		},
+	})
+
+	assert.Equal(t, &amplitude.Event{
+		EventType: ExposureEventType,
+		UserID:    "user-1",
+		DeviceID:  "device-1",
+		EventProperties: map[string]interface{}{
+			"flag_key":       "new-player",
+			"variant":        "on",
+			"experiment_key": "exp-1",
+		},
+		UserProperties: map[string]interface{}{
+			"$set": map[string]interface{}{
+				"[Experiment] new-player": "on",
+			},
+		},
+	}, event)
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/euskadi31/go-amplitude/internal/api"
+)
+
+// DayFormat is the layout of the start and end days of the Dashboard REST API.
+const DayFormat = "20060102"
+
+// Metrics of event segmentation.
+const (
+	MetricUniques   = "uniques"
+	MetricTotals    = "totals"
+	MetricPctDAU    = "pct_dau"
+	MetricAverage   = "average"
+	MetricHistogram = "histogram"
+	MetricSums      = "sums"
+	MetricValueAvg  = "value_avg"
+)
+
+// Intervals of event segmentation.
+const (
+	IntervalRealtime = -300000
+	IntervalHourly   = -3600000
+	IntervalDaily    = 1
+	IntervalWeekly   = 7
+	IntervalMonthly  = 30
+)
+
+// Filter of a segmentation event.
+type Filter struct {
+	SubpropType  string   `json:"subprop_type"`
+	SubpropKey   string   `json:"subprop_key"`
+	SubpropOp    string   `json:"subprop_op"`
+	SubpropValue []string `json:"subprop_value"`
+}
+
+// GroupBy of a segmentation event.
+type GroupBy struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// SegmentationEvent is the event of a segmentation query.
+type SegmentationEvent struct {
+	EventType string     `json:"event_type"`
+	Filters   []*Filter  `json:"filters,omitempty"`
+	GroupBy   []*GroupBy `json:"group_by,omitempty"`
+}
+
+// SegmentationQuery struct.
+type SegmentationQuery struct {
+	Event    *SegmentationEvent
+	Start    time.Time
+	End      time.Time
+	Metric   string
+	Interval int
+	Limit    int
+}
+
+// SegmentationResult struct.
+type SegmentationResult struct {
+	Series       [][]float64   `json:"series"`
+	SeriesLabels []interface{} `json:"seriesLabels"`
+	XValues      []string      `json:"xValues"`
+}
+
+// Segmentation returns the event segmentation of query.
+func (c *Client) Segmentation(ctx context.Context, query *SegmentationQuery) (*SegmentationResult, error) {
+	e, err := json.Marshal(query.Event)
+	if err != nil {
+		return nil, fmt.Errorf("json encode segmentation event failed: %w", err)
+	}
+
+	q := url.Values{
+		"e":     {string(e)},
+		"start": {query.Start.Format(DayFormat)},
+		"end":   {query.End.Format(DayFormat)},
+	}
+
+	if query.Metric != "" {
+		q.Set("m", query.Metric)
+	}
+
+	if query.Interval != 0 {
+		q.Set("i", strconv.Itoa(query.Interval))
+	}
+
+	if query.Limit > 0 {
+		q.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	resp := &struct {
+		Data *SegmentationResult `json:"data"`
+	}{}
+
+	if err := c.api.JSON(ctx, &api.Request{
+		Method: http.MethodGet,
+		Path:   "/api/2/events/segmentation",
+		Query:  q,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Data == nil {
+		return &SegmentationResult{}, nil
+	}
+
+	return resp.Data, nil
+}
+
+// UserData is the summary of a user returned by UserActivity.
+type UserData struct {
+	UserID               string                 `json:"user_id"`
+	CanonicalAmplitudeID int64                  `json:"canonical_amplitude_id"`
+	NumEvents            int                    `json:"num_events"`
+	NumSessions          int                    `json:"num_sessions"`
+	FirstUsed            string                 `json:"first_used"`
+	LastUsed             string                 `json:"last_used"`
+	Country              string                 `json:"country"`
+	Platform             string                 `json:"platform"`
+	OS                   string                 `json:"os"`
+	Device               string                 `json:"device"`
+	Properties           map[string]interface{} `json:"properties"`
+}
+
+// UserActivity struct.
+type UserActivity struct {
+	UserData *UserData
+	Events   []*amplitude.Event
+}
+
+// UserActivity returns the user data and the events of a user, most recent first.
+func (c *Client) UserActivity(ctx context.Context, amplitudeID int64, offset int, limit int) (*UserActivity, error) {
+	q := url.Values{
+		"user": {strconv.FormatInt(amplitudeID, 10)},
+	}
+
+	if offset > 0 {
+		q.Set("offset", strconv.Itoa(offset))
+	}
+
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	resp := &struct {
+		UserData *UserData         `json:"userData"`
+		Events   []json.RawMessage `json:"events"`
+	}{}
+
+	if err := c.api.JSON(ctx, &api.Request{
+		Method: http.MethodGet,
+		Path:   "/api/2/useractivity",
+		Query:  q,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	activity := &UserActivity{
+		UserData: resp.UserData,
+		Events:   make([]*amplitude.Event, 0, len(resp.Events)),
+	}
+
+	for _, raw := range resp.Events {
+		event, err := decodeEvent(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		activity.Events = append(activity.Events, event)
+	}
+
+	return activity, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package export
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientSegmentation(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _, ok := r.BasicAuth()
+		assert.True(t, ok)
+
+		assert.Equal(t, "/api/2/events/segmentation", r.URL.Path)
+
+		q := r.URL.Query()
+
+		assert.Equal(t, `{"event_type":"user.created","filters":[{"subprop_type":"event","subprop_key":"from","subprop_op":"is","subprop_value":["mobile"]}]}`, q.Get("e"))
+		assert.Equal(t, "20261001", q.Get("start"))
+		assert.Equal(t, "20261002", q.Get("end"))
+		assert.Equal(t, "totals", q.Get("m"))
+		assert.Equal(t, "1", q.Get("i"))
+		assert.Equal(t, "10", q.Get("limit"))
+
+		w.Write([]byte(`{"data":{"series":[[12,15]],"seriesLabels":[0],"xValues":["2026-10-01","2026-10-02"]}}`))
+	}))
+	defer ts.Close()
+
+	c := New("key", "secret", WithURL(ts.URL))
+
+	result, err := c.Segmentation(context.Background(), &SegmentationQuery{
+		Event: &SegmentationEvent{
+			EventType: "user.created",
+			Filters: []*Filter{
+				{
+					SubpropType:  "event",
+					SubpropKey:   "from",
+					SubpropOp:    "is",
+					SubpropValue: []string{"mobile"},
+				},
+			},
+		},
+		Start:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC),
+		Metric:   MetricTotals,
+		Interval: IntervalDaily,
+		Limit:    10,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, &SegmentationResult{
+		Series:       [][]float64{{12, 15}},
+		SeriesLabels: []interface{}{float64(0)},
+		XValues:      []string{"2026-10-01", "2026-10-02"},
+	}, result)
+}
+
+func TestClientSegmentationEmpty(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	c := New("key", "secret", WithURL(ts.URL))
+
+	result, err := c.Segmentation(context.Background(), &SegmentationQuery{
+		Event: &SegmentationEvent{
+			EventType: "user.created",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &SegmentationResult{}, result)
+}
+
+func TestClientUserActivity(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/2/useractivity", r.URL.Path)
+		assert.Equal(t, "123", r.URL.Query().Get("user"))
+		assert.Equal(t, "10", r.URL.Query().Get("offset"))
+		assert.Equal(t, "5", r.URL.Query().Get("limit"))
+
+		w.Write([]byte(`{"userData":{"user_id":"user-1","canonical_amplitude_id":123,"num_events":2,"properties":{"plan":"premium"}},"events":[{"event_type":"user.created","event_time":"2026-10-19 10:00:00.000000"}]}`))
+	}))
+	defer ts.Close()
+
+	c := New("key", "secret", WithURL(ts.URL))
+
+	activity, err := c.UserActivity(context.Background(), 123, 10, 5)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "user-1", activity.UserData.UserID)
+	assert.Equal(t, int64(123), activity.UserData.CanonicalAmplitudeID)
+	assert.Equal(t, 2, activity.UserData.NumEvents)
+	assert.Equal(t, "premium", activity.UserData.Properties["plan"])
+	assert.Len(t, activity.Events, 1)
+	assert.Equal(t, "user.created", activity.Events[0].EventType)
+	assert.Equal(t, int64(1792404000), activity.Events[0].Timestamp)
+}
+
+func TestClientUserActivityError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"events":[{"event_time":"yesterday"}]}`))
+	}))
+	defer ts.Close()
+
+	c := New("key", "secret", WithURL(ts.URL))
+
+	_, err := c.UserActivity(context.Background(), 123, 0, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+)
+
+// EventTimeFormat is the layout of the event times of exported events.
+const EventTimeFormat = "2006-01-02 15:04:05.999999"
+
+// exportedEvent maps the fields of exported events that differ from the HTTP API.
+type exportedEvent struct {
+	*amplitude.Event
+	EventTime     string `json:"event_time"`
+	DeviceCarrier string `json:"device_carrier"`
+	IPAddress     string `json:"ip_address"`
+	InsertID      string `json:"$insert_id"`
+}
+
+func decodeEvent(b []byte) (*amplitude.Event, error) {
+	e := &exportedEvent{
+		Event: &amplitude.Event{},
+	}
+
+	if err := json.Unmarshal(b, e); err != nil {
+		return nil, fmt.Errorf("json decode event failed: %w", err)
+	}
+
+	if e.EventTime != "" {
+		t, err := time.Parse(EventTimeFormat, e.EventTime)
+		if err != nil {
+			return nil, fmt.Errorf("parse event time failed: %w", err)
+		}
+
+		e.Timestamp = t.Unix()
+	}
+
+	if e.Carrier == "" {
+		e.Carrier = e.DeviceCarrier
+	}
+
+	if e.IP == "" {
+		e.IP = e.IPAddress
+	}
+
+	if e.Event.InsertID == "" {
+		e.Event.InsertID = e.InsertID
+	}
+
+	return e.Event, nil
+}
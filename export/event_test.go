@@ -0,0 +1,37 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package export
+
+import (
+	"testing"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	event, err := decodeEvent([]byte(`{"event_type":"user.created","user_id":"user-1","device_id":"device-1","event_time":"2026-10-19 10:00:00","device_carrier":"Orange","ip_address":"1.2.3.4","$insert_id":"abc","platform":"iOS","session_id":1760868000000,"user_properties":{"plan":"premium"}}`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, &amplitude.Event{
+		EventType: "user.created",
+		UserID:    "user-1",
+		DeviceID:  "device-1",
+		Timestamp: 1792404000,
+		Carrier:   "Orange",
+		IP:        "1.2.3.4",
+		InsertID:  "abc",
+		Platform:  "iOS",
+		SessionID: 1760868000000,
+		UserProperties: map[string]interface{}{
+			"plan": "premium",
+		},
+	}, event)
+}
+
+func TestDecodeEventInvalidTime(t *testing.T) {
+	_, err := decodeEvent([]byte(`{"event_type":"user.created","event_time":"yesterday"}`))
+	assert.Error(t, err)
+}
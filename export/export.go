@@ -0,0 +1,259 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package export implements a client of the Amplitude Export API and Dashboard REST API.
+// see: https://amplitude.com/docs/apis/analytics/export
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/euskadi31/go-amplitude/internal/api"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	StandardEndpoint    = api.StandardEndpoint
+	EUResidencyEndpoint = api.EUResidencyEndpoint
+)
+
+// HourFormat is the layout of the start and end hours of the Export API.
+const HourFormat = "20060102T15"
+
+// maxLineSize is the maximum size of an exported event.
+const maxLineSize = 10 * 1024 * 1024
+
+// Client of the Export API and Dashboard REST API.
+type Client struct {
+	api *api.Client
+}
+
+// New Export API client authenticated with the API key and secret key of the project.
+func New(apiKey string, secretKey string, opts ...Option) *Client {
+	c := &Client{
+		api: api.New(StandardEndpoint),
+	}
+
+	// Exports of large time ranges take minutes.
+	c.api.Timeout = time.Minute * 10
+	c.api.Authorize = api.BasicAuth(apiKey, secretKey)
+
+	api.Apply(c, c.api, opts...)
+
+	return c
+}
+
+// Export downloads the events received between the start and end hours,
+// included, and returns an iterator over them. The archive is downloaded to
+// a temporary file removed by Iterator.Close.
+func (c *Client) Export(ctx context.Context, start time.Time, end time.Time) (*Iterator, error) {
+	resp, err := c.api.Do(ctx, &api.Request{
+		Method: http.MethodGet,
+		Path:   "/api/2/export",
+		Query: url.Values{
+			"start": {start.UTC().Format(HourFormat)},
+			"end":   {end.UTC().Format(HourFormat)},
+		},
+	})
+	if err != nil {
+		var apiErr *amplitude.APIError
+
+		// The API responds with 404 when there is no data for the time range.
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return &Iterator{}, nil
+		}
+
+		return nil, err
+	}
+
+	defer api.CloseBody(resp)
+
+	f, err := os.CreateTemp("", "amplitude-export-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("create temporary file failed: %w", err)
+	}
+
+	it := &Iterator{
+		file: f,
+	}
+
+	size, err := io.Copy(f, resp.Body)
+	if err != nil {
+		it.discard()
+
+		return nil, fmt.Errorf("download export failed: %w", err)
+	}
+
+	it.archive, err = zip.NewReader(f, size)
+	if err != nil {
+		it.discard()
+
+		return nil, fmt.Errorf("open export archive failed: %w", err)
+	}
+
+	return it, nil
+}
+
+// Iterator over exported events.
+//
+//	for it.Next() {
+//		event := it.Event()
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator struct {
+	file    *os.File
+	archive *zip.Reader
+	index   int
+	reader  io.ReadCloser
+	gzip    *gzip.Reader
+	scanner *bufio.Scanner
+	event   *amplitude.Event
+	err     error
+}
+
+// Next advances to the next event, it returns false when there are no more
+// events or an error occurred.
+func (it *Iterator) Next() bool {
+	if it.err != nil || it.archive == nil {
+		return false
+	}
+
+	for {
+		if it.scanner == nil {
+			if it.index >= len(it.archive.File) {
+				return false
+			}
+
+			if err := it.open(it.archive.File[it.index]); err != nil {
+				it.err = err
+
+				return false
+			}
+
+			it.index++
+		}
+
+		if it.scanner.Scan() {
+			line := it.scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+
+			event, err := decodeEvent(line)
+			if err != nil {
+				it.err = err
+
+				return false
+			}
+
+			it.event = event
+
+			return true
+		}
+
+		if err := it.scanner.Err(); err != nil {
+			it.err = fmt.Errorf("read exported events failed: %w", err)
+
+			return false
+		}
+
+		it.closeFile()
+	}
+}
+
+func (it *Iterator) open(f *zip.File) error {
+	r, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("open %s failed: %w", f.Name, err)
+	}
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		if err := r.Close(); err != nil {
+			log.Error().Err(err).Msgf("close %s failed", f.Name)
+		}
+
+		return fmt.Errorf("gzip open %s failed: %w", f.Name, err)
+	}
+
+	it.reader = r
+	it.gzip = gz
+	it.scanner = bufio.NewScanner(gz)
+	it.scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	return nil
+}
+
+func (it *Iterator) closeFile() {
+	if it.gzip != nil {
+		if err := it.gzip.Close(); err != nil {
+			log.Error().Err(err).Msg("gzip close failed")
+		}
+	}
+
+	if it.reader != nil {
+		if err := it.reader.Close(); err != nil {
+			log.Error().Err(err).Msg("close exported file failed")
+		}
+	}
+
+	it.gzip = nil
+	it.reader = nil
+	it.scanner = nil
+}
+
+// Event returns the current event.
+func (it *Iterator) Event() *amplitude.Event {
+	return it.event
+}
+
+// Err returns the error that stopped the iteration.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+func (it *Iterator) discard() {
+	if err := it.Close(); err != nil {
+		log.Error().Err(err).Msg("discard export failed")
+	}
+}
+
+// Close releases the archive and removes the temporary file.
+func (it *Iterator) Close() error {
+	it.closeFile()
+
+	if it.file == nil {
+		return nil
+	}
+
+	name := it.file.Name()
+
+	err := it.file.Close()
+
+	it.file = nil
+
+	if rmErr := os.Remove(name); rmErr != nil && err == nil {
+		err = rmErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("close export failed: %w", err)
+	}
+
+	return nil
+}
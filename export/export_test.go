@@ -0,0 +1,182 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+
+	zw := zip.NewWriter(buf)
+
+	for name, content := range files {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+
+		gz := gzip.NewWriter(w)
+
+		_, err = gz.Write([]byte(content))
+		assert.NoError(t, err)
+
+		assert.NoError(t, gz.Close())
+	}
+
+	assert.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func TestClientExport(t *testing.T) {
+	archive := newArchive(t, map[string]string{
+		"123/123_2026-10-19_10#0.json.gz": `{"event_type":"user.created","user_id":"user-1","event_time":"2026-10-19 10:00:00.123000","device_carrier":"Orange","ip_address":"1.2.3.4","$insert_id":"abc","event_properties":{"from":"mobile"}}
+{"event_type":"user.updated","user_id":"user-1","event_time":"2026-10-19 10:30:00.000000"}
+`,
+		"123/123_2026-10-19_11#0.json.gz": `
+{"event_type":"user.deleted","user_id":"user-2","event_time":"2026-10-19 11:00:00.000000"}
+`,
+	})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "key", user)
+		assert.Equal(t, "secret", pass)
+
+		assert.Equal(t, "/api/2/export", r.URL.Path)
+		assert.Equal(t, "20261019T10", r.URL.Query().Get("start"))
+		assert.Equal(t, "20261019T11", r.URL.Query().Get("end"))
+
+		w.Write(archive)
+	}))
+	defer ts.Close()
+
+	c := New("key", "secret", WithURL(ts.URL))
+
+	it, err := c.Export(
+		context.Background(),
+		time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC),
+	)
+	assert.NoError(t, err)
+
+	name := it.file.Name()
+
+	var types []string
+
+	for it.Next() {
+		types = append(types, it.Event().EventType)
+
+		if it.Event().EventType == "user.created" {
+			assert.Equal(t, "user-1", it.Event().UserID)
+			assert.Equal(t, int64(1792404000), it.Event().Timestamp)
+			assert.Equal(t, "Orange", it.Event().Carrier)
+			assert.Equal(t, "1.2.3.4", it.Event().IP)
+			assert.Equal(t, "abc", it.Event().InsertID)
+			assert.Equal(t, map[string]interface{}{"from": "mobile"}, it.Event().EventProperties)
+		}
+	}
+
+	assert.NoError(t, it.Err())
+	assert.ElementsMatch(t, []string{"user.created", "user.updated", "user.deleted"}, types)
+
+	assert.NoError(t, it.Close())
+
+	_, err = os.Stat(name)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestClientExportNoData(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no data", http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c := New("key", "secret", WithURL(ts.URL))
+
+	it, err := c.Export(context.Background(), time.Now(), time.Now())
+	assert.NoError(t, err)
+
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+	assert.NoError(t, it.Close())
+}
+
+func TestClientExportError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid time range", http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	c := New("key", "secret", WithURL(ts.URL))
+
+	_, err := c.Export(context.Background(), time.Now(), time.Now())
+	assert.EqualError(t, err, "400: invalid time range")
+}
+
+func TestClientExportInvalidArchive(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not a zip"))
+	}))
+	defer ts.Close()
+
+	c := New("key", "secret", WithURL(ts.URL))
+
+	_, err := c.Export(context.Background(), time.Now(), time.Now())
+	assert.Error(t, err)
+}
+
+func TestIteratorInvalidFiles(t *testing.T) {
+	for name, archive := range map[string][]byte{
+		"gzip": func() []byte {
+			buf := &bytes.Buffer{}
+
+			zw := zip.NewWriter(buf)
+
+			w, err := zw.Create("events.json.gz")
+			assert.NoError(t, err)
+
+			_, err = w.Write([]byte("not gzip"))
+			assert.NoError(t, err)
+
+			assert.NoError(t, zw.Close())
+
+			return buf.Bytes()
+		}(),
+		"json": newArchive(t, map[string]string{
+			"events.json.gz": `{"event_type":`,
+		}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write(archive)
+			}))
+			defer ts.Close()
+
+			c := New("key", "secret", WithURL(ts.URL))
+
+			it, err := c.Export(context.Background(), time.Now(), time.Now())
+			assert.NoError(t, err)
+
+			assert.False(t, it.Next())
+			assert.Error(t, it.Err())
+			assert.False(t, it.Next())
+			assert.NoError(t, it.Close())
+		})
+	}
+}
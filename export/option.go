@@ -0,0 +1,19 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package export
+
+import "github.com/euskadi31/go-amplitude/internal/api"
+
+// Option configures a Client.
+type Option = api.Option[Client]
+
+// The options shared by the REST API clients.
+var (
+	WithURL           = api.WithURL[Client]
+	WithTimeout       = api.WithTimeout[Client]
+	WithMaxRetry      = api.WithMaxRetry[Client]
+	WithRetryInterval = api.WithRetryInterval[Client]
+	WithHTTPClient    = api.WithHTTPClient[Client]
+)
@@ -0,0 +1,286 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/clock"
+	"github.com/rs/zerolog/log"
+)
+
+// FilterAction defines what happens to the events matching a filter rule.
+type FilterAction int
+
+const (
+	// FilterAllow keeps the events.
+	FilterAllow FilterAction = iota
+
+	// FilterDeny drops the events.
+	FilterDeny
+)
+
+func (a FilterAction) String() string {
+	switch a {
+	case FilterAllow:
+		return "allow"
+	case FilterDeny:
+		return "deny"
+	default:
+		return fmt.Sprintf("FilterAction(%d)", int(a))
+	}
+}
+
+// MarshalJSON encodes the action as "allow" or "deny".
+func (a FilterAction) MarshalJSON() ([]byte, error) {
+	switch a {
+	case FilterAllow, FilterDeny:
+		return json.Marshal(a.String())
+	default:
+		return nil, fmt.Errorf("invalid filter action %d", int(a))
+	}
+}
+
+// UnmarshalJSON decodes "allow" or "deny".
+func (a *FilterAction) UnmarshalJSON(b []byte) error {
+	var s string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("invalid filter action: %w", err)
+	}
+
+	switch s {
+	case "allow":
+		*a = FilterAllow
+	case "deny":
+		*a = FilterDeny
+	default:
+		return fmt.Errorf("invalid filter action %q", s)
+	}
+
+	return nil
+}
+
+// FilterRule selects events, all its criteria must match.
+type FilterRule struct {
+	Action FilterAction `json:"action"`
+
+	// EventType is a path.Match pattern of the event type, e.g. "page.*".
+	EventType string `json:"event_type,omitempty"`
+
+	// Platform is a path.Match pattern of the platform.
+	Platform string `json:"platform,omitempty"`
+
+	// Properties are event properties and their values.
+	Properties map[string]interface{} `json:"properties,omitempty"`
+
+	// Match is an additional predicate, it cannot be loaded from JSON.
+	Match EventMatcher `json:"-"`
+}
+
+func (r *FilterRule) matches(event *Event) bool {
+	if r.EventType != "" {
+		if ok, _ := path.Match(r.EventType, event.EventType); !ok {
+			return false
+		}
+	}
+
+	if r.Platform != "" {
+		if ok, _ := path.Match(r.Platform, event.Platform); !ok {
+			return false
+		}
+	}
+
+	for name, value := range r.Properties {
+		if !matchProperty(event.EventProperties, name, []interface{}{value}) {
+			return false
+		}
+	}
+
+	return r.Match == nil || r.Match(event)
+}
+
+// FilterRules are applied in order, the action of the first matching rule
+// is applied and Default when no rule matches.
+type FilterRules struct {
+	Rules   []*FilterRule `json:"rules"`
+	Default FilterAction  `json:"default"`
+}
+
+// LoadFilterRules decodes JSON filter rules.
+func LoadFilterRules(r io.Reader) (*FilterRules, error) {
+	rules := &FilterRules{}
+
+	if err := json.NewDecoder(r).Decode(rules); err != nil {
+		return nil, fmt.Errorf("decode filter rules failed: %w", err)
+	}
+
+	if err := rules.Compile(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// LoadFilterRulesFile decodes a JSON filter rules file.
+func LoadFilterRulesFile(filename string) (*FilterRules, error) {
+	f, err := os.Open(filename) //nolint:gosec // filename is configured by the library consumer, not user input
+	if err != nil {
+		return nil, fmt.Errorf("open filter rules failed: %w", err)
+	}
+
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Error().Err(err).Msg("close filter rules failed")
+		}
+	}()
+
+	return LoadFilterRules(f)
+}
+
+// Compile checks the patterns of the rules, it is called by LoadFilterRules and Filter.Update.
+func (r *FilterRules) Compile() error {
+	for i, rule := range r.Rules {
+		if rule == nil {
+			return fmt.Errorf("filter rule %d: nil rule", i)
+		}
+
+		for _, pattern := range []string{rule.EventType, rule.Platform} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("filter rule %d: invalid pattern %q: %w", i, pattern, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Action returns the action applied to event.
+func (r *FilterRules) Action(event *Event) FilterAction {
+	for _, rule := range r.Rules {
+		if rule.matches(event) {
+			return rule.Action
+		}
+	}
+
+	return r.Default
+}
+
+// FilterLoader returns the filter rules, e.g. from a file or a configuration service.
+type FilterLoader func(ctx context.Context) (*FilterRules, error)
+
+// FilterRulesFile returns a FilterLoader reading a JSON filter rules file.
+func FilterRulesFile(filename string) FilterLoader {
+	return func(_ context.Context) (*FilterRules, error) {
+		return LoadFilterRulesFile(filename)
+	}
+}
+
+// Filter is a before plugin dropping the events denied by its rules,
+// which can be replaced at runtime, see Update and Watch.
+type Filter struct {
+	rules atomic.Pointer[FilterRules]
+
+	mtx   sync.Mutex
+	clock clock.Clock
+}
+
+var _ Plugin = (*Filter)(nil)
+
+// NewFilter returns a Filter applying rules, see WithFilter.
+func NewFilter(rules *FilterRules) (*Filter, error) {
+	f := &Filter{
+		clock: clock.Real(),
+	}
+
+	if err := f.Update(rules); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Rules returns the rules applied.
+func (f *Filter) Rules() *FilterRules {
+	return f.rules.Load()
+}
+
+// Update replaces the rules, they are applied to the next events.
+func (f *Filter) Update(rules *FilterRules) error {
+	if rules == nil {
+		rules = &FilterRules{}
+	}
+
+	if err := rules.Compile(); err != nil {
+		return err
+	}
+
+	f.rules.Store(rules)
+
+	return nil
+}
+
+// Reload replaces the rules with the ones returned by load.
+func (f *Filter) Reload(ctx context.Context, load FilterLoader) error {
+	rules, err := load(ctx)
+	if err != nil {
+		return fmt.Errorf("load filter rules failed: %w", err)
+	}
+
+	return f.Update(rules)
+}
+
+// Watch reloads the rules every interval until ctx is done, the rules are
+// kept when the reload fails. The interval is measured with the clock of the
+// client using the filter, when Watch is called after New.
+func (f *Filter) Watch(ctx context.Context, interval time.Duration, load FilterLoader) {
+	ticker := f.getClock().NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			if err := f.Reload(ctx, load); err != nil {
+				log.Error().Err(err).Msg("Amplitude filter reload failed")
+			}
+		}
+	}
+}
+
+func (f *Filter) setClock(clk clock.Clock) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.clock = clk
+}
+
+func (f *Filter) getClock() clock.Clock {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	return f.clock
+}
+
+func (f *Filter) Type() PluginType {
+	return PluginTypeBefore
+}
+
+func (f *Filter) Execute(_ context.Context, event *Event) ([]*Event, error) {
+	if f.Rules().Action(event) == FilterDeny {
+		return nil, nil
+	}
+
+	return []*Event{event}, nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterActionJSON(t *testing.T) {
+	b, err := json.Marshal([]FilterAction{FilterAllow, FilterDeny})
+	assert.NoError(t, err)
+	assert.Equal(t, `["allow","deny"]`, string(b))
+
+	_, err = json.Marshal(FilterAction(42))
+	assert.Error(t, err)
+
+	var actions []FilterAction
+
+	assert.NoError(t, json.Unmarshal(b, &actions))
+	assert.Equal(t, []FilterAction{FilterAllow, FilterDeny}, actions)
+
+	var action FilterAction
+
+	assert.Error(t, json.Unmarshal([]byte(`"block"`), &action))
+	assert.Error(t, json.Unmarshal([]byte(`1`), &action))
+
+	assert.Equal(t, "FilterAction(42)", FilterAction(42).String())
+}
+
+func TestLoadFilterRulesFile(t *testing.T) {
+	rules, err := LoadFilterRulesFile("testdata/filter_rules.json")
+	assert.NoError(t, err)
+
+	assert.Len(t, rules.Rules, 3)
+	assert.Equal(t, FilterAllow, rules.Default)
+
+	assert.Equal(t, FilterAllow, rules.Action(&Event{EventType: "page.heartbeat", Platform: "ios"}))
+	assert.Equal(t, FilterDeny, rules.Action(&Event{EventType: "page.heartbeat", Platform: "android"}))
+	assert.Equal(t, FilterDeny, rules.Action(&Event{EventType: "page.viewed"}))
+	assert.Equal(t, FilterDeny, rules.Action(&Event{EventType: "user.created", EventProperties: map[string]interface{}{"internal": true}}))
+	assert.Equal(t, FilterAllow, rules.Action(&Event{EventType: "user.created", EventProperties: map[string]interface{}{"internal": false}}))
+	assert.Equal(t, FilterAllow, rules.Action(&Event{EventType: "user.created"}))
+}
+
+func TestLoadFilterRulesNumeric(t *testing.T) {
+	rules, err := LoadFilterRules(strings.NewReader(`{"rules": [{"action": "deny", "properties": {"version": 2}}]}`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, FilterDeny, rules.Action(&Event{EventProperties: map[string]interface{}{"version": 2}}))
+	assert.Equal(t, FilterDeny, rules.Action(&Event{EventProperties: map[string]interface{}{"version": int64(2)}}))
+	assert.Equal(t, FilterDeny, rules.Action(&Event{EventProperties: map[string]interface{}{"version": 2.0}}))
+	assert.Equal(t, FilterDeny, rules.Action(&Event{EventProperties: map[string]interface{}{"version": json.Number("2")}}))
+	assert.Equal(t, FilterAllow, rules.Action(&Event{EventProperties: map[string]interface{}{"version": 3}}))
+	assert.Equal(t, FilterAllow, rules.Action(&Event{EventProperties: map[string]interface{}{"version": "2"}}))
+}
+
+func TestLoadFilterRulesFileNotFound(t *testing.T) {
+	_, err := LoadFilterRulesFile("testdata/not_found.json")
+	assert.Error(t, err)
+}
+
+func TestLoadFilterRulesInvalid(t *testing.T) {
+	_, err := LoadFilterRules(strings.NewReader(`{"rules": [`))
+	assert.Error(t, err)
+
+	_, err = LoadFilterRules(strings.NewReader(`{"rules": [{"action": "deny", "event_type": "page.["}]}`))
+	assert.ErrorIs(t, err, path.ErrBadPattern)
+
+	_, err = LoadFilterRules(strings.NewReader(`{"rules": [null]}`))
+	assert.Error(t, err)
+}
+
+func TestFilter(t *testing.T) {
+	f, err := NewFilter(&FilterRules{
+		Rules: []*FilterRule{
+			{
+				Action: FilterAllow,
+				Match: func(event *Event) bool {
+					return event.UserID == "admin"
+				},
+			},
+		},
+		Default: FilterDeny,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, PluginTypeBefore, f.Type())
+
+	events, err := f.Execute(context.Background(), &Event{EventType: "user.created", UserID: "admin"})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	events, err = f.Execute(context.Background(), &Event{EventType: "user.created", UserID: "user-1"})
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+
+	// Without rules everything is allowed.
+	assert.NoError(t, f.Update(nil))
+
+	events, err = f.Execute(context.Background(), &Event{EventType: "user.created", UserID: "user-1"})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	// Invalid rules are not applied.
+	assert.Error(t, f.Update(&FilterRules{Rules: []*FilterRule{{Platform: "["}}}))
+	assert.Equal(t, &FilterRules{}, f.Rules())
+
+	_, err = NewFilter(&FilterRules{Rules: []*FilterRule{{EventType: "["}}})
+	assert.Error(t, err)
+}
+
+func TestFilterReload(t *testing.T) {
+	f, err := NewFilter(nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, f.Reload(context.Background(), FilterRulesFile("testdata/filter_rules.json")))
+	assert.Len(t, f.Rules().Rules, 3)
+
+	errLoad := errors.New("load failed")
+
+	err = f.Reload(context.Background(), func(ctx context.Context) (*FilterRules, error) {
+		return nil, errLoad
+	})
+	assert.ErrorIs(t, err, errLoad)
+	assert.Len(t, f.Rules().Rules, 3)
+}
+
+func TestFilterWatch(t *testing.T) {
+	f, err := NewFilter(nil)
+	assert.NoError(t, err)
+
+	var loads int32
+
+	fake := clock.NewFake(time.Now())
+
+	// The filter uses the clock of the client.
+	newClient("foo", WithClock(fake), WithFilter(f))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		f.Watch(ctx, time.Minute, func(ctx context.Context) (*FilterRules, error) {
+			if atomic.AddInt32(&loads, 1) == 1 {
+				return nil, errors.New("load failed")
+			}
+
+			return &FilterRules{Default: FilterDeny}, nil
+		})
+	}()
+
+	fake.BlockUntil(1)
+
+	// The rules are kept when the reload fails.
+	fake.Advance(time.Minute)
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&loads) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, FilterAllow, f.Rules().Default)
+
+	fake.Advance(time.Minute)
+
+	assert.Eventually(t, func() bool {
+		return f.Rules().Default == FilterDeny
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&loads))
+}
+
+func TestClientWithFilter(t *testing.T) {
+	f, err := NewFilter(&FilterRules{
+		Rules: []*FilterRule{
+			{Action: FilterDeny, EventType: "page.heartbeat"},
+		},
+	})
+	assert.NoError(t, err)
+
+	var enriched int32
+
+	enrich := NewPlugin(PluginTypeBefore, func(_ context.Context, event *Event) ([]*Event, error) {
+		atomic.AddInt32(&enriched, 1)
+
+		return []*Event{event}, nil
+	})
+
+	c := newClient("foo", WithPlugins(enrich), WithFilter(f))
+
+	assert.Same(t, f, c.plugins[0])
+
+	events, err := processEvent(context.Background(), c.plugins, &Event{EventType: "page.heartbeat"})
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+
+	events, err = processEvent(context.Background(), c.plugins, &Event{EventType: "page.viewed"})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	// The denied events are not seen by the plugins added before the filter.
+	assert.Equal(t, int32(1), atomic.LoadInt32(&enriched))
+}
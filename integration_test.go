@@ -13,8 +13,15 @@ import (
 )
 
 func TestIntegration(t *testing.T) {
+	key := os.Getenv("DEMO_AMPLITUDE_API_KEY")
+	if key == "" {
+		t.Skip("DEMO_AMPLITUDE_API_KEY is not set")
+	}
+
+	var errs []error
+
 	c := New(
-		os.Getenv("DEMO_AMPLITUDE_API_KEY"),
+		key,
 		WithURL(StandardEndpoint),
 		WithTimeout(time.Second*2),
 		WithInterval(time.Second*5),
@@ -22,8 +29,10 @@ func TestIntegration(t *testing.T) {
 		WithBufferSize(2),
 		WithMaxRetry(2),
 		WithRetryInterval(time.Second*5),
+		WithCallback(func(payload *Payload, err error) {
+			errs = append(errs, err)
+		}),
 	)
-	defer c.Close()
 
 	err := c.Enqueue(&Event{
 		UserID:      "f892be22-8f8e-445d-83b0-af199b9a5c72",
@@ -39,5 +48,7 @@ func TestIntegration(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	time.Sleep(15 * time.Second)
+	// Close flushes the event and attempts the pending retries.
+	assert.NoError(t, c.Close())
+	assert.Equal(t, []error{nil}, errs)
 }
@@ -0,0 +1,283 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package api implements the HTTP plumbing shared by the Amplitude REST API clients.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	StandardEndpoint    = "https://amplitude.com"
+	EUResidencyEndpoint = "https://analytics.eu.amplitude.com"
+)
+
+// UserAgent of the REST API clients.
+const UserAgent = "Amplitude Golang Client (https://github.com/euskadi31/go-amplitude)"
+
+// maxErrorSize is the maximum number of bytes of an error response kept in APIError.
+const maxErrorSize = 4096
+
+// Client of the Amplitude REST APIs.
+type Client struct {
+	Endpoint      string
+	Timeout       time.Duration
+	MaxRetry      int
+	RetryInterval time.Duration
+	HTTPClient    *http.Client
+	Authorize     func(r *http.Request)
+}
+
+// New returns a Client with the default configuration.
+func New(endpoint string) *Client {
+	return &Client{
+		Endpoint:      endpoint,
+		Timeout:       time.Second * 10,
+		MaxRetry:      3,
+		RetryInterval: time.Second * 1,
+	}
+}
+
+// BasicAuth authorizes requests with the API key and secret key of a project.
+func BasicAuth(apiKey string, secretKey string) func(r *http.Request) {
+	return func(r *http.Request) {
+		r.SetBasicAuth(apiKey, secretKey)
+	}
+}
+
+// APIKeyAuth authorizes requests with an "Api-Key" authorization header.
+func APIKeyAuth(key string) func(r *http.Request) {
+	return func(r *http.Request) {
+		r.Header.Set("Authorization", "Api-Key "+key)
+	}
+}
+
+// Init creates the HTTP client when none was configured, it must be called once the options are applied.
+func (c *Client) Init() {
+	if c.HTTPClient == nil {
+		c.HTTPClient = &http.Client{
+			Timeout: c.Timeout,
+		}
+	}
+}
+
+// Request to an Amplitude REST API.
+type Request struct {
+	Method      string
+	Path        string
+	Query       url.Values
+	Body        []byte
+	ContentType string
+
+	// Idempotent allows the retries of a POST on server errors and timeouts,
+	// e.g. when it only reads data.
+	Idempotent bool
+}
+
+// idempotent reports whether the request can be sent again after it may have been processed.
+func (r *Request) idempotent() bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return r.Idempotent
+	}
+}
+
+// NewJSONRequest returns a request with a JSON body.
+func NewJSONRequest(method string, path string, body interface{}) (*Request, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("json encode request failed: %w", err)
+	}
+
+	return &Request{
+		Method:      method,
+		Path:        path,
+		Body:        b,
+		ContentType: "application/json",
+	}, nil
+}
+
+// NewFormRequest returns a request with a form encoded body.
+func NewFormRequest(method string, path string, form url.Values) *Request {
+	return &Request{
+		Method:      method,
+		Path:        path,
+		Body:        []byte(form.Encode()),
+		ContentType: "application/x-www-form-urlencoded",
+	}
+}
+
+// URL returns the URL of the request.
+func (c *Client) URL(req *Request) string {
+	u := strings.TrimSuffix(c.Endpoint, "/") + req.Path
+
+	if len(req.Query) > 0 {
+		u += "?" + req.Query.Encode()
+	}
+
+	return u
+}
+
+// Do sends the request, retrying on 429 responses and connection failures.
+// Idempotent requests are also retried on other network errors and 5xx
+// responses, a non idempotent one could have been processed. The caller
+// must close the body of the response. Responses with an error status are
+// returned as *amplitude.APIError.
+func (c *Client) Do(ctx context.Context, req *Request) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.MaxRetry; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, c.RetryInterval*time.Duration(attempt)); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.do(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		lastErr = err
+
+		if !shouldRetry(ctx, req, err) {
+			return nil, err
+		}
+
+		log.Debug().Err(err).Int("attempt", attempt+1).Msgf("Amplitude %s %s failed", req.Method, req.Path)
+	}
+
+	return nil, lastErr
+}
+
+func shouldRetry(ctx context.Context, req *Request, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var apiErr *amplitude.APIError
+
+	if errors.As(err, &apiErr) {
+		if apiErr.StatusCode == http.StatusTooManyRequests {
+			return true
+		}
+
+		return req.idempotent() && apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return req.idempotent() || notConnected(err)
+}
+
+// notConnected reports whether err happened before a connection was made, so the request was not sent.
+func notConnected(err error) bool {
+	var dnsErr *net.DNSError
+
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func (c *Client) do(ctx context.Context, req *Request) (*http.Response, error) {
+	var body io.Reader
+
+	if req.Body != nil {
+		body = bytes.NewReader(req.Body)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, req.Method, c.URL(req), body)
+	if err != nil {
+		return nil, fmt.Errorf("http new request failed: %w", err)
+	}
+
+	if req.ContentType != "" {
+		r.Header.Set("Content-Type", req.ContentType)
+	}
+
+	r.Header.Set("Accept", "application/json")
+	r.Header.Set("User-Agent", UserAgent)
+
+	if c.Authorize != nil {
+		c.Authorize(r)
+	}
+
+	resp, err := c.HTTPClient.Do(r) //nolint:gosec // endpoint is configured by the library consumer, not user input
+	if err != nil {
+		return nil, fmt.Errorf("http client send request failed: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+
+	defer CloseBody(resp)
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorSize))
+	if err != nil {
+		return nil, fmt.Errorf("read response body failed: %w", err)
+	}
+
+	return nil, &amplitude.APIError{
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(b)),
+	}
+}
+
+// JSON sends the request and decodes the JSON response into out, when not nil.
+func (c *Client) JSON(ctx context.Context, req *Request, out interface{}) error {
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	defer CloseBody(resp)
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("json decode response failed: %w", err)
+	}
+
+	return nil
+}
+
+// CloseBody closes the body of resp and logs the error.
+func CloseBody(resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		log.Error().Err(err).Msg("http client close response body failed")
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("retry canceled: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(endpoint string) *Client {
+	c := New(endpoint)
+	c.RetryInterval = time.Millisecond
+	c.Init()
+
+	return c
+}
+
+func TestClientJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/2/foo", r.URL.Path)
+		assert.Equal(t, "bar", r.URL.Query().Get("q"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, UserAgent, r.Header.Get("User-Agent"))
+
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "key", user)
+		assert.Equal(t, "secret", pass)
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"name":"foo"}`, string(b))
+
+		w.Write([]byte(`{"id":42}`))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL + "/")
+	c.Authorize = BasicAuth("key", "secret")
+
+	req, err := NewJSONRequest(http.MethodPost, "/api/2/foo", map[string]string{"name": "foo"})
+	assert.NoError(t, err)
+
+	req.Query = url.Values{"q": {"bar"}}
+
+	out := struct {
+		ID int `json:"id"`
+	}{}
+
+	assert.NoError(t, c.JSON(context.Background(), req, &out))
+	assert.Equal(t, 42, out.ID)
+}
+
+func TestClientRetry(t *testing.T) {
+	hits := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+
+		assert.Equal(t, "Api-Key secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "name=foo", string(b))
+
+		if hits < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	c.Authorize = APIKeyAuth("secret")
+
+	err := c.JSON(context.Background(), NewFormRequest(http.MethodPost, "/foo", url.Values{"name": {"foo"}}), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, hits)
+}
+
+func TestClientError(t *testing.T) {
+	hits := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+
+		if r.URL.Path == "/bad" {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+
+	err := c.JSON(context.Background(), &Request{Method: http.MethodGet, Path: "/bad"}, nil)
+
+	var apiErr *amplitude.APIError
+
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "invalid request", apiErr.Message)
+	assert.Equal(t, 1, hits)
+
+	hits = 0
+
+	err = c.JSON(context.Background(), &Request{Method: http.MethodGet, Path: "/unavailable"}, nil)
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, 4, hits)
+}
+
+func TestClientContextCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	c.RetryInterval = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	err := c.JSON(ctx, &Request{Method: http.MethodGet, Path: "/"}, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClientInvalidJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{`))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+
+	out := map[string]interface{}{}
+
+	assert.Error(t, c.JSON(context.Background(), &Request{Method: http.MethodGet, Path: "/"}, &out))
+}
+
+func TestClientRetryPost(t *testing.T) {
+	hits := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+
+	// A POST could have been processed, it is not retried.
+	err := c.JSON(context.Background(), &Request{Method: http.MethodPost, Path: "/"}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, hits)
+
+	hits = 0
+
+	err = c.JSON(context.Background(), &Request{Method: http.MethodPost, Path: "/", Idempotent: true}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 4, hits)
+}
+
+func TestClientRetryNetworkError(t *testing.T) {
+	var hits atomic.Int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+
+		// The connection is closed after the request was received.
+		conn, _, err := w.(http.Hijacker).Hijack()
+		assert.NoError(t, err)
+		assert.NoError(t, conn.Close())
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+
+	assert.Error(t, c.JSON(context.Background(), &Request{Method: http.MethodPost, Path: "/"}, nil))
+	assert.Equal(t, int32(1), hits.Load())
+
+	hits.Store(0)
+
+	assert.Error(t, c.JSON(context.Background(), &Request{Method: http.MethodDelete, Path: "/"}, nil))
+	assert.Equal(t, int32(4), hits.Load())
+}
+
+func TestShouldRetry(t *testing.T) {
+	ctx := context.Background()
+	post := &Request{Method: http.MethodPost}
+	get := &Request{Method: http.MethodGet}
+
+	assert.True(t, shouldRetry(ctx, post, &amplitude.APIError{StatusCode: http.StatusTooManyRequests}))
+	assert.False(t, shouldRetry(ctx, post, &amplitude.APIError{StatusCode: http.StatusBadGateway}))
+	assert.True(t, shouldRetry(ctx, get, &amplitude.APIError{StatusCode: http.StatusBadGateway}))
+	assert.False(t, shouldRetry(ctx, get, &amplitude.APIError{StatusCode: http.StatusBadRequest}))
+
+	// The connection was never made.
+	assert.True(t, shouldRetry(ctx, post, &net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+	assert.True(t, shouldRetry(ctx, post, &net.DNSError{Err: "no such host"}))
+	assert.False(t, shouldRetry(ctx, post, &net.OpError{Op: "read", Err: errors.New("connection reset")}))
+	assert.True(t, shouldRetry(ctx, get, &net.OpError{Op: "read", Err: errors.New("connection reset")}))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	assert.False(t, shouldRetry(canceled, get, errors.New("failed")))
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a REST API client of type T and its Client, the options
+// below are shared by all the REST API clients.
+type Option[T any] func(c *T, api *Client)
+
+// Apply applies opts to c and api, then calls Init.
+func Apply[T any](c *T, api *Client, opts ...Option[T]) {
+	for _, opt := range opts {
+		opt(c, api)
+	}
+
+	api.Init()
+}
+
+// WithURL sets the endpoint of the API.
+func WithURL[T any](url string) Option[T] {
+	return func(_ *T, c *Client) {
+		c.Endpoint = url
+	}
+}
+
+// WithTimeout sets the timeout of the HTTP client created when none is configured.
+func WithTimeout[T any](timeout time.Duration) Option[T] {
+	return func(_ *T, c *Client) {
+		c.Timeout = timeout
+	}
+}
+
+// WithMaxRetry sets the number of retries of a request.
+func WithMaxRetry[T any](retry int) Option[T] {
+	return func(_ *T, c *Client) {
+		c.MaxRetry = retry
+	}
+}
+
+// WithRetryInterval sets the interval between the retries of a request.
+func WithRetryInterval[T any](interval time.Duration) Option[T] {
+	return func(_ *T, c *Client) {
+		c.RetryInterval = interval
+	}
+}
+
+// WithHTTPClient sets the HTTP client sending the requests.
+func WithHTTPClient[T any](httpClient *http.Client) Option[T] {
+	return func(_ *T, c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testClient struct {
+	name string
+}
+
+func withName(name string) Option[testClient] {
+	return func(c *testClient, _ *Client) {
+		c.name = name
+	}
+}
+
+func TestApply(t *testing.T) {
+	hc := &http.Client{}
+	c := &testClient{}
+	a := New(StandardEndpoint)
+
+	Apply(
+		c,
+		a,
+		WithURL[testClient](EUResidencyEndpoint),
+		WithTimeout[testClient](time.Second*2),
+		WithMaxRetry[testClient](5),
+		WithRetryInterval[testClient](time.Second*3),
+		WithHTTPClient[testClient](hc),
+		withName("foo"),
+	)
+
+	assert.Equal(t, EUResidencyEndpoint, a.Endpoint)
+	assert.Equal(t, time.Second*2, a.Timeout)
+	assert.Equal(t, 5, a.MaxRetry)
+	assert.Equal(t, time.Second*3, a.RetryInterval)
+	assert.Same(t, hc, a.HTTPClient)
+	assert.Equal(t, "foo", c.name)
+}
+
+func TestApplyWithTimeout(t *testing.T) {
+	a := New(StandardEndpoint)
+
+	Apply(&testClient{}, a, WithTimeout[testClient](time.Second*2))
+
+	assert.Equal(t, time.Second*2, a.HTTPClient.Timeout)
+}
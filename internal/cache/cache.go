@@ -0,0 +1,117 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package cache implements an in-memory cache with expiration.
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type item[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Cache of values expiring after a TTL, safe for concurrent use.
+type Cache[V any] struct {
+	mtx     sync.Mutex
+	ttl     time.Duration
+	maxSize int
+	items   map[string]item[V]
+	now     func() time.Time
+}
+
+// New returns a cache of values expiring after ttl, holding at most maxSize
+// values when maxSize is greater than 0.
+func New[V any](ttl time.Duration, maxSize int) *Cache[V] {
+	return &Cache[V]{
+		ttl:     ttl,
+		maxSize: maxSize,
+		items:   map[string]item[V]{},
+		now:     time.Now,
+	}
+}
+
+// Get returns the value of key if it has not expired.
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	it, ok := c.items[key]
+	if !ok {
+		var zero V
+
+		return zero, false
+	}
+
+	if !c.now().Before(it.expiresAt) {
+		delete(c.items, key)
+
+		var zero V
+
+		return zero, false
+	}
+
+	return it.value, true
+}
+
+// Set stores the value of key.
+func (c *Cache[V]) Set(key string, value V) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := c.now()
+
+	if _, ok := c.items[key]; !ok && c.maxSize > 0 && len(c.items) >= c.maxSize {
+		c.evict(now)
+	}
+
+	c.items[key] = item[V]{
+		value:     value,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// evict removes the expired values, or the value expiring first when none has expired.
+func (c *Cache[V]) evict(now time.Time) {
+	var (
+		oldest    string
+		oldestExp time.Time
+	)
+
+	for key, it := range c.items {
+		if !now.Before(it.expiresAt) {
+			delete(c.items, key)
+
+			continue
+		}
+
+		if oldest == "" || it.expiresAt.Before(oldestExp) {
+			oldest = key
+			oldestExp = it.expiresAt
+		}
+	}
+
+	if len(c.items) >= c.maxSize {
+		delete(c.items, oldest)
+	}
+}
+
+// Delete removes the value of key.
+func (c *Cache[V]) Delete(key string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.items, key)
+}
+
+// Len returns the number of values stored, including the expired ones not yet removed.
+func (c *Cache[V]) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return len(c.items)
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	c := New[int](time.Minute, 0)
+	c.now = func() time.Time {
+		return now
+	}
+
+	_, ok := c.Get("foo")
+	assert.False(t, ok)
+
+	c.Set("foo", 1)
+
+	v, ok := c.Get("foo")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	now = now.Add(time.Minute)
+
+	_, ok = c.Get("foo")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+
+	c.Set("foo", 2)
+	c.Delete("foo")
+
+	_, ok = c.Get("foo")
+	assert.False(t, ok)
+}
+
+func TestCacheMaxSize(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	c := New[string](time.Minute, 2)
+	c.now = func() time.Time {
+		return now
+	}
+
+	c.Set("a", "a")
+
+	now = now.Add(time.Second)
+
+	c.Set("b", "b")
+	c.Set("b", "b")
+
+	assert.Equal(t, 2, c.Len())
+
+	c.Set("c", "c")
+
+	assert.Equal(t, 2, c.Len())
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	now = now.Add(time.Minute)
+
+	c.Set("d", "d")
+
+	assert.Equal(t, 1, c.Len())
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package properties implements the copies and merges of event properties
+// shared by the amplitude client and its test double.
+package properties
+
+// Clone returns a deep copy of props, nil when props is nil.
+func Clone(props map[string]interface{}) map[string]interface{} {
+	if props == nil {
+		return nil
+	}
+
+	clone := make(map[string]interface{}, len(props))
+
+	for key, value := range props {
+		clone[key] = CloneValue(value)
+	}
+
+	return clone
+}
+
+// CloneValue returns a deep copy of the maps and slices of value.
+func CloneValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return Clone(v)
+	case []interface{}:
+		clone := make([]interface{}, len(v))
+
+		for i, item := range v {
+			clone[i] = CloneValue(item)
+		}
+
+		return clone
+	case []string:
+		return append([]string(nil), v...)
+	default:
+		return value
+	}
+}
+
+// Merge returns dst with the keys of src it misses, in a new map when src
+// adds keys: the map of the caller may be marshalled concurrently.
+func Merge(dst map[string]interface{}, src map[string]interface{}) map[string]interface{} {
+	var merged map[string]interface{}
+
+	for key, value := range src {
+		if _, ok := dst[key]; ok {
+			continue
+		}
+
+		if merged == nil {
+			merged = make(map[string]interface{}, len(dst)+len(src))
+
+			for k, v := range dst {
+				merged[k] = v
+			}
+		}
+
+		merged[key] = CloneValue(value)
+	}
+
+	if merged == nil {
+		return dst
+	}
+
+	return merged
+}
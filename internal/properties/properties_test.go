@@ -0,0 +1,61 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package properties
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	assert.Nil(t, Clone(nil))
+
+	props := map[string]interface{}{
+		"a":    1,
+		"map":  map[string]interface{}{"b": 2},
+		"list": []interface{}{map[string]interface{}{"c": 3}},
+		"tags": []string{"d"},
+	}
+
+	clone := Clone(props)
+
+	assert.Equal(t, props, clone)
+
+	clone["map"].(map[string]interface{})["b"] = 4
+	clone["list"].([]interface{})[0].(map[string]interface{})["c"] = 5
+	clone["tags"].([]string)[0] = "e"
+
+	assert.Equal(t, map[string]interface{}{
+		"a":    1,
+		"map":  map[string]interface{}{"b": 2},
+		"list": []interface{}{map[string]interface{}{"c": 3}},
+		"tags": []string{"d"},
+	}, props)
+}
+
+func TestMerge(t *testing.T) {
+	dst := map[string]interface{}{"a": 1}
+
+	// Nothing to add, dst is returned as is.
+	merged := Merge(dst, map[string]interface{}{"a": 2})
+	merged["b"] = 2
+	assert.Equal(t, map[string]interface{}{"a": 1, "b": 2}, dst)
+
+	dst = map[string]interface{}{"a": 1}
+
+	assert.Equal(t, map[string]interface{}{"a": 1, "b": 2}, Merge(dst, map[string]interface{}{"a": 3, "b": 2}))
+	assert.Equal(t, map[string]interface{}{"a": 1}, dst)
+
+	assert.Equal(t, map[string]interface{}{"b": 2}, Merge(nil, map[string]interface{}{"b": 2}))
+	assert.Nil(t, Merge(nil, nil))
+
+	// The values of src are copied.
+	src := map[string]interface{}{"map": map[string]interface{}{"c": 3}}
+
+	merged = Merge(nil, src)
+	merged["map"].(map[string]interface{})["c"] = 4
+	assert.Equal(t, map[string]interface{}{"map": map[string]interface{}{"c": 3}}, src)
+}
@@ -5,8 +5,11 @@
 package amplitude
 
 import (
+	"io"
 	"net/http"
 	"time"
+
+	"github.com/euskadi31/go-amplitude/clock"
 )
 
 type Option func(*client)
@@ -64,3 +67,124 @@ func WithHTTPClient(httpClient *http.Client) Option {
 		c.httpClient = httpClient
 	}
 }
+
+func WithPlugins(plugins ...Plugin) Option {
+	return func(c *client) {
+		c.plugins = append(c.plugins, plugins...)
+	}
+}
+
+func WithTrackingPlan(plan *TrackingPlan, mode ValidationMode) Option {
+	return WithPlugins(&planValidator{
+		plan: plan,
+		mode: mode,
+	})
+}
+
+// WithDefaults merges defaults into each event, fields set on the event win.
+func WithDefaults(defaults *Event) Option {
+	return WithPlugins(&defaultsPlugin{
+		defaults: defaults,
+	})
+}
+
+// WithRedaction redacts the event data, before the tracking plan validation
+// whatever the order of the options.
+func WithRedaction(config RedactionConfig) Option {
+	return func(c *client) {
+		redaction := &redactionPlugin{
+			config: config,
+		}
+
+		for i, plugin := range c.plugins {
+			if _, ok := plugin.(*planValidator); ok {
+				c.plugins = append(c.plugins[:i], append([]Plugin{redaction}, c.plugins[i:]...)...)
+
+				return
+			}
+		}
+
+		c.plugins = append(c.plugins, redaction)
+	}
+}
+
+// WithConsent drops or limits the events according to the consent of users,
+// categorize is optional and required to restrict event categories.
+func WithConsent(provider ConsentProvider, categorize EventCategorizer) Option {
+	return WithPlugins(&consentPlugin{
+		provider:   provider,
+		categorize: categorize,
+	})
+}
+
+// WithUserMapURL sets the endpoint of the usermap API, it defaults to the region of WithURL.
+func WithUserMapURL(url string) Option {
+	return func(c *client) {
+		c.userMapURL = url
+	}
+}
+
+// Callback is called with the outcome of the delivery of each payload, err
+// is nil once sent or the *DeliveryError of the last attempt when dropped.
+type Callback func(payload *Payload, err error)
+
+// WithCallback sets the callback reporting the delivery of payloads.
+func WithCallback(callback Callback) Option {
+	return func(c *client) {
+		c.callback = callback
+	}
+}
+
+// WithAttributionURL sets the endpoint of the attribution API, it defaults to the region of WithURL.
+func WithAttributionURL(url string) Option {
+	return func(c *client) {
+		c.attributionURL = url
+	}
+}
+
+// WithDryRun writes the payloads to w instead of sending them, see DryRunEnv.
+func WithDryRun(w io.Writer, format DryRunFormat) Option {
+	return func(c *client) {
+		c.dryRun = &payloadWriter{
+			w:      w,
+			format: format,
+		}
+	}
+}
+
+// WithDebug writes the payloads to w before sending them, see DebugEnv.
+func WithDebug(w io.Writer, format DryRunFormat) Option {
+	return func(c *client) {
+		c.debug = &payloadWriter{
+			w:      w,
+			format: format,
+		}
+	}
+}
+
+// WithClock sets the clock driving the flush interval, the retry backoff and
+// the default timestamp of the events, see clock.NewFake for tests.
+func WithClock(clk clock.Clock) Option {
+	return func(c *client) {
+		c.clock = clk
+	}
+}
+
+// WithRateLimiter limits the rate of the events of each device and user before batching,
+// the limiter uses the clock of the client when its config has none.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return WithPlugins(limiter)
+}
+
+// WithSampling keeps the events of a fraction of the users for some event types.
+func WithSampling(sampler *Sampler) Option {
+	return WithPlugins(sampler)
+}
+
+// WithFilter drops the events denied by filter before they are queued, the
+// filter runs before the other plugins whatever the order of the options.
+func WithFilter(filter *Filter) Option {
+	return func(c *client) {
+		c.plugins = append([]Plugin{filter}, c.plugins...)
+	}
+}
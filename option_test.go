@@ -5,10 +5,12 @@
 package amplitude
 
 import (
+	"bytes"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/euskadi31/go-amplitude/clock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -85,3 +87,171 @@ func TestWithHTTPClient(t *testing.T) {
 
 	assert.Equal(t, hc, c.httpClient)
 }
+
+func TestWithTrackingPlan(t *testing.T) {
+	c := &client{}
+
+	plan := &TrackingPlan{}
+
+	WithTrackingPlan(plan, ValidationWarn)(c)
+
+	assert.Equal(t, []Plugin{&planValidator{plan: plan, mode: ValidationWarn}}, c.plugins)
+}
+
+func TestWithPlugins(t *testing.T) {
+	c := &client{}
+
+	p1 := NewPlugin(PluginTypeBefore, nil)
+	p2 := NewPlugin(PluginTypeDestination, nil)
+
+	WithPlugins(p1)(c)
+	WithPlugins(p2)(c)
+
+	assert.Equal(t, []Plugin{p1, p2}, c.plugins)
+}
+
+func TestWithDefaults(t *testing.T) {
+	c := &client{}
+
+	defaults := &Event{
+		AppVersion: "1.2.3",
+	}
+
+	WithDefaults(defaults)(c)
+
+	assert.Equal(t, []Plugin{&defaultsPlugin{defaults: defaults}}, c.plugins)
+}
+
+func TestWithRedaction(t *testing.T) {
+	c := &client{}
+
+	config := RedactionConfig{
+		Rules: []RedactionRule{
+			{Path: "ip", Action: RedactDrop},
+		},
+	}
+
+	WithRedaction(config)(c)
+
+	assert.Equal(t, []Plugin{&redactionPlugin{config: config}}, c.plugins)
+
+	// The redaction runs before the tracking plan validation.
+	c = &client{}
+
+	plan := &TrackingPlan{}
+	defaults := &Event{Platform: "web"}
+
+	WithDefaults(defaults)(c)
+	WithTrackingPlan(plan, ValidationWarn)(c)
+	WithRedaction(config)(c)
+
+	assert.Equal(t, []Plugin{
+		&defaultsPlugin{defaults: defaults},
+		&redactionPlugin{config: config},
+		&planValidator{plan: plan, mode: ValidationWarn},
+	}, c.plugins)
+}
+
+func TestWithConsent(t *testing.T) {
+	c := &client{}
+
+	provider := NewMemoryConsentProvider(Consent{})
+
+	WithConsent(provider, nil)(c)
+
+	assert.Equal(t, []Plugin{&consentPlugin{provider: provider}}, c.plugins)
+}
+
+func TestWithUserMapURL(t *testing.T) {
+	c := &client{}
+
+	WithUserMapURL("https://api.amplitude.tld/usermap")(c)
+
+	assert.Equal(t, "https://api.amplitude.tld/usermap", c.userMapURL)
+}
+
+func TestWithCallback(t *testing.T) {
+	c := &client{}
+
+	called := false
+
+	WithCallback(func(payload *Payload, err error) {
+		called = true
+	})(c)
+
+	c.report(&Payload{}, nil)
+
+	assert.True(t, called)
+}
+
+func TestWithAttributionURL(t *testing.T) {
+	c := &client{}
+
+	WithAttributionURL("https://api.amplitude.tld/attribution")(c)
+
+	assert.Equal(t, "https://api.amplitude.tld/attribution", c.attributionURL)
+}
+
+func TestWithDryRun(t *testing.T) {
+	c := &client{}
+
+	buf := &bytes.Buffer{}
+
+	WithDryRun(buf, DryRunNDJSON)(c)
+
+	assert.Equal(t, &payloadWriter{w: buf, format: DryRunNDJSON}, c.dryRun)
+}
+
+func TestWithDebug(t *testing.T) {
+	c := &client{}
+
+	buf := &bytes.Buffer{}
+
+	WithDebug(buf, DryRunPretty)(c)
+
+	assert.Equal(t, &payloadWriter{w: buf, format: DryRunPretty}, c.debug)
+}
+
+func TestWithClock(t *testing.T) {
+	c := &client{}
+
+	fake := clock.NewFake(time.Now())
+
+	WithClock(fake)(c)
+
+	assert.Equal(t, fake, c.clock)
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	c := &client{}
+
+	limiter := NewRateLimiter(RateLimitConfig{Rate: 10})
+
+	WithRateLimiter(limiter)(c)
+
+	assert.Equal(t, []Plugin{limiter}, c.plugins)
+}
+
+func TestWithSampling(t *testing.T) {
+	c := &client{}
+
+	sampler, err := NewSampler(SamplingConfig{
+		Rates: map[string]float64{"page.heartbeat": 0.1},
+	})
+	assert.NoError(t, err)
+
+	WithSampling(sampler)(c)
+
+	assert.Equal(t, []Plugin{sampler}, c.plugins)
+}
+
+func TestWithFilter(t *testing.T) {
+	c := &client{}
+
+	filter, err := NewFilter(nil)
+	assert.NoError(t, err)
+
+	WithFilter(filter)(c)
+
+	assert.Equal(t, []Plugin{filter}, c.plugins)
+}
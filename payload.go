@@ -16,10 +16,27 @@ type PayloadOptions struct {
 	MinIDLength int `json:"min_id_length"`
 }
 
+// PayloadKind defines the API a Payload is sent to.
+type PayloadKind int
+
+const (
+	// PayloadEvents is a batch of events sent to the HTTP API.
+	PayloadEvents PayloadKind = iota
+
+	// PayloadUserMap is a batch of user mappings sent to the usermap API.
+	PayloadUserMap
+
+	// PayloadAttribution is an attribution event sent to the attribution API.
+	PayloadAttribution
+)
+
 type Payload struct {
-	Body     []byte
-	Attempts int
-	Size     int
+	Kind        PayloadKind
+	URL         string
+	ContentType string
+	Body        []byte
+	Attempts    int
+	Size        int
 }
 
 type ErrorResponse struct {
@@ -0,0 +1,129 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+
+	"github.com/euskadi31/go-amplitude/clock"
+	"github.com/rs/zerolog/log"
+)
+
+// clockSetter is implemented by the plugins using the clock of the client when none is configured.
+type clockSetter interface {
+	setClock(clk clock.Clock)
+}
+
+// PluginType defines the stage of the pipeline a plugin is executed in.
+type PluginType int
+
+const (
+	// PluginTypeBefore plugins are executed first, e.g. to filter events.
+	PluginTypeBefore PluginType = iota
+
+	// PluginTypeEnrichment plugins are executed after the before plugins, e.g. to add properties.
+	PluginTypeEnrichment
+
+	// PluginTypeDestination plugins receive the processed events in addition to Amplitude.
+	PluginTypeDestination
+)
+
+// Plugin processes events on Enqueue, before they are batched.
+//
+// Before and enrichment plugins are executed in order and return the events
+// passed to the next plugin: none to drop the event, several to fan it out.
+// An error aborts Enqueue. Destination plugins receive each processed event,
+// the events they return are ignored and their errors are logged.
+//
+// Plugins are called by Enqueue and must be safe for concurrent use.
+type Plugin interface {
+	Type() PluginType
+	Execute(ctx context.Context, event *Event) ([]*Event, error)
+}
+
+// PluginFunc is the signature of the function of a plugin created with NewPlugin.
+type PluginFunc func(ctx context.Context, event *Event) ([]*Event, error)
+
+type funcPlugin struct {
+	typ PluginType
+	fn  PluginFunc
+}
+
+// NewPlugin returns a Plugin of the given type executing fn.
+func NewPlugin(typ PluginType, fn PluginFunc) Plugin {
+	return &funcPlugin{
+		typ: typ,
+		fn:  fn,
+	}
+}
+
+func (p *funcPlugin) Type() PluginType {
+	return p.typ
+}
+
+func (p *funcPlugin) Execute(ctx context.Context, event *Event) ([]*Event, error) {
+	return p.fn(ctx, event)
+}
+
+// processEvent executes the before then enrichment plugins on event.
+func processEvent(ctx context.Context, plugins []Plugin, event *Event) ([]*Event, error) {
+	events := []*Event{event}
+
+	for _, typ := range []PluginType{PluginTypeBefore, PluginTypeEnrichment} {
+		for _, plugin := range plugins {
+			if plugin.Type() != typ {
+				continue
+			}
+
+			next := make([]*Event, 0, len(events))
+
+			for _, e := range events {
+				out, err := plugin.Execute(ctx, e)
+				if err != nil {
+					return nil, err
+				}
+
+				next = append(next, out...)
+			}
+
+			if len(next) == 0 {
+				return nil, nil
+			}
+
+			events = next
+		}
+	}
+
+	return events, nil
+}
+
+// destinationEvent returns a copy of event for the destination plugins, nil without
+// destination plugin. The queued event is marshalled concurrently and must not be shared.
+func destinationEvent(plugins []Plugin, event *Event) *Event {
+	for _, plugin := range plugins {
+		if plugin.Type() == PluginTypeDestination {
+			return event.Clone()
+		}
+	}
+
+	return nil
+}
+
+// deliverEvent sends event to the destination plugins, nil is ignored.
+func deliverEvent(ctx context.Context, plugins []Plugin, event *Event) {
+	if event == nil {
+		return
+	}
+
+	for _, plugin := range plugins {
+		if plugin.Type() != PluginTypeDestination {
+			continue
+		}
+
+		if _, err := plugin.Execute(ctx, event); err != nil {
+			log.Error().Err(err).Str("event_type", event.EventType).Msg("destination plugin failed")
+		}
+	}
+}
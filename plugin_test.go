@@ -0,0 +1,235 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessEvent(t *testing.T) {
+	var calls []string
+
+	plugins := []Plugin{
+		NewPlugin(PluginTypeEnrichment, func(ctx context.Context, event *Event) ([]*Event, error) {
+			calls = append(calls, "enrichment:"+event.EventType)
+
+			return []*Event{event}, nil
+		}),
+		NewPlugin(PluginTypeDestination, func(ctx context.Context, event *Event) ([]*Event, error) {
+			calls = append(calls, "destination:"+event.EventType)
+
+			return nil, nil
+		}),
+		NewPlugin(PluginTypeBefore, func(ctx context.Context, event *Event) ([]*Event, error) {
+			calls = append(calls, "before:"+event.EventType)
+
+			clone := event.Clone()
+			clone.EventType = "bar"
+
+			return []*Event{event, clone}, nil
+		}),
+	}
+
+	events, err := processEvent(context.Background(), plugins, &Event{EventType: "foo"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*Event{{EventType: "foo"}, {EventType: "bar"}}, events)
+	assert.Equal(t, []string{"before:foo", "enrichment:foo", "enrichment:bar"}, calls)
+
+	calls = nil
+
+	deliverEvent(context.Background(), plugins, events[0])
+
+	assert.Equal(t, []string{"destination:foo"}, calls)
+}
+
+func TestProcessEventDropped(t *testing.T) {
+	called := false
+
+	plugins := []Plugin{
+		NewPlugin(PluginTypeBefore, func(ctx context.Context, event *Event) ([]*Event, error) {
+			return nil, nil
+		}),
+		NewPlugin(PluginTypeEnrichment, func(ctx context.Context, event *Event) ([]*Event, error) {
+			called = true
+
+			return []*Event{event}, nil
+		}),
+	}
+
+	events, err := processEvent(context.Background(), plugins, &Event{EventType: "foo"})
+	assert.NoError(t, err)
+
+	assert.Empty(t, events)
+	assert.False(t, called)
+}
+
+func TestProcessEventError(t *testing.T) {
+	errPlugin := errors.New("plugin failed")
+
+	plugins := []Plugin{
+		NewPlugin(PluginTypeBefore, func(ctx context.Context, event *Event) ([]*Event, error) {
+			return nil, errPlugin
+		}),
+	}
+
+	events, err := processEvent(context.Background(), plugins, &Event{EventType: "foo"})
+	assert.ErrorIs(t, err, errPlugin)
+	assert.Nil(t, events)
+}
+
+func TestDeliverEventError(t *testing.T) {
+	called := 0
+
+	plugins := []Plugin{
+		NewPlugin(PluginTypeDestination, func(ctx context.Context, event *Event) ([]*Event, error) {
+			called++
+
+			return nil, errors.New("destination failed")
+		}),
+		NewPlugin(PluginTypeDestination, func(ctx context.Context, event *Event) ([]*Event, error) {
+			called++
+
+			return nil, nil
+		}),
+	}
+
+	deliverEvent(context.Background(), plugins, &Event{EventType: "foo"})
+
+	assert.Equal(t, 2, called)
+}
+
+func TestClientWithPlugins(t *testing.T) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer wg.Done()
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		defer r.Body.Close()
+
+		assert.Equal(t, `{"api_key":"foo","events":[{"event_type":"user.created","time":1643367217,"event_properties":{"service":"api"}}]}`, string(b))
+	}))
+	defer ts.Close()
+
+	var mtx sync.Mutex
+
+	var delivered []*Event
+
+	c := New(
+		"foo",
+		WithURL(ts.URL),
+		WithInterval(time.Millisecond*100),
+		WithPlugins(
+			NewPlugin(PluginTypeBefore, func(ctx context.Context, event *Event) ([]*Event, error) {
+				if event.EventType == "heartbeat" {
+					return nil, nil
+				}
+
+				return []*Event{event}, nil
+			}),
+			NewPlugin(PluginTypeEnrichment, func(ctx context.Context, event *Event) ([]*Event, error) {
+				event.EventProperties = map[string]interface{}{
+					"service": "api",
+				}
+
+				return []*Event{event}, nil
+			}),
+			NewPlugin(PluginTypeDestination, func(ctx context.Context, event *Event) ([]*Event, error) {
+				mtx.Lock()
+				defer mtx.Unlock()
+
+				delivered = append(delivered, event)
+
+				return nil, nil
+			}),
+		),
+	)
+	defer c.Close()
+
+	assert.NoError(t, c.Enqueue(&Event{
+		EventType: "heartbeat",
+		Timestamp: 1643367217,
+	}))
+
+	assert.NoError(t, c.Enqueue(&Event{
+		EventType: "user.created",
+		Timestamp: 1643367217,
+	}))
+
+	wg.Wait()
+
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	assert.Len(t, delivered, 1)
+	assert.Equal(t, "user.created", delivered[0].EventType)
+}
+
+func TestDestinationEvent(t *testing.T) {
+	event := &Event{EventType: "foo", EventProperties: map[string]interface{}{"a": 1}}
+
+	assert.Nil(t, destinationEvent([]Plugin{NewPlugin(PluginTypeBefore, nil)}, event))
+
+	dest := destinationEvent([]Plugin{NewPlugin(PluginTypeDestination, nil)}, event)
+	assert.Equal(t, event, dest)
+	assert.NotSame(t, event, dest)
+
+	deliverEvent(context.Background(), []Plugin{NewPlugin(PluginTypeDestination, nil)}, nil)
+}
+
+// TestClientDestinationPluginMutation is meant to be run with -race: the
+// destination plugins run while the loop marshals the queued event.
+func TestClientDestinationPluginMutation(t *testing.T) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer wg.Done()
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		defer r.Body.Close()
+
+		assert.Equal(t, `{"api_key":"foo","events":[{"event_type":"user.created","time":1643367217,"event_properties":{"service":"api"}}]}`, string(b))
+	}))
+	defer ts.Close()
+
+	c := New(
+		"foo",
+		WithURL(ts.URL),
+		WithInterval(time.Millisecond*10),
+		WithPlugins(NewPlugin(PluginTypeDestination, func(ctx context.Context, event *Event) ([]*Event, error) {
+			event.EventProperties["destination"] = "warehouse"
+			event.EventType = "mutated"
+
+			return nil, nil
+		})),
+	)
+	defer c.Close()
+
+	assert.NoError(t, c.Enqueue(&Event{
+		EventType:       "user.created",
+		Timestamp:       1643367217,
+		EventProperties: map[string]interface{}{"service": "api"},
+	}))
+
+	wg.Wait()
+}
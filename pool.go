@@ -0,0 +1,428 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/clock"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrMissingAPIKey is returned by the Pool when the API key of a tenant is empty.
+	ErrMissingAPIKey = errors.New("api key is required")
+
+	// ErrQuotaExceeded is returned by the Pool when the buffer of a tenant is full.
+	ErrQuotaExceeded = errors.New("tenant buffer quota exceeded")
+)
+
+// PoolOption configures a Pool.
+type PoolOption func(*Pool)
+
+// WithPoolWorkers sets the number of workers sharing the tenants, GOMAXPROCS by
+// default. There is at least one worker.
+func WithPoolWorkers(workers int) PoolOption {
+	return func(p *Pool) {
+		if workers < 1 {
+			workers = 1
+		}
+
+		p.workers = make([]*poolWorker, workers)
+	}
+}
+
+// WithTenantOptions sets the options of the clients of the tenants. The
+// interval of the workers and their clock are those of these options.
+func WithTenantOptions(opts ...Option) PoolOption {
+	return func(p *Pool) {
+		p.opts = append(p.opts, opts...)
+	}
+}
+
+// WithTenantQuota sets the maximum number of events and user mappings buffered for a tenant, 10000 by default.
+func WithTenantQuota(quota int) PoolOption {
+	return func(p *Pool) {
+		p.quota = quota
+	}
+}
+
+// WithTenantIdleTimeout sets the time after which a tenant with nothing left to send is removed, 10 minutes by default.
+func WithTenantIdleTimeout(timeout time.Duration) PoolOption {
+	return func(p *Pool) {
+		p.idleTimeout = timeout
+	}
+}
+
+// Pool sends the events of many projects, the tenants, keyed by their API
+// key. The tenants are created on their first event and share the HTTP
+// client and a fixed number of workers, each flushing the batches of its
+// tenants on a single ticker.
+type Pool struct {
+	opts        []Option
+	quota       int
+	idleTimeout time.Duration
+	config      *client
+	workers     []*poolWorker
+	quitCh      chan struct{}
+}
+
+// NewPool starts a pool, it must be closed to flush the buffered events.
+func NewPool(opts ...PoolOption) *Pool {
+	p := &Pool{
+		quota:       10000,
+		idleTimeout: time.Minute * 10,
+		workers:     make([]*poolWorker, runtime.GOMAXPROCS(0)),
+		quitCh:      make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// config holds the settings shared by the tenants, it never sends anything.
+	p.config = newClient("", p.opts...)
+
+	for i := range p.workers {
+		p.workers[i] = &poolWorker{
+			pool:       p,
+			tenants:    map[string]*tenant{},
+			flushCh:    make(chan struct{}, 1),
+			shutdownCh: make(chan struct{}, 1),
+		}
+
+		go p.workers[i].loop()
+	}
+
+	return p
+}
+
+// Enqueue enqueues event for the tenant of apiKey.
+func (p *Pool) Enqueue(apiKey string, event *Event) error {
+	return p.EnqueueContext(context.Background(), apiKey, event)
+}
+
+// EnqueueContext enqueues event for the tenant of apiKey with the event properties carried by ctx,
+// ErrQuotaExceeded is returned when the buffer of the tenant is full.
+func (p *Pool) EnqueueContext(ctx context.Context, apiKey string, event *Event) error {
+	if apiKey == "" {
+		return ErrMissingAPIKey
+	}
+
+	if event.Timestamp == 0 {
+		event.Timestamp = p.config.clock.Now().UTC().Unix()
+	}
+
+	addContextProperties(ctx, event)
+
+	events, err := processEvent(ctx, p.config.plugins, event)
+	if err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	dests := make([]*Event, 0, len(events))
+
+	for _, e := range events {
+		dests = append(dests, destinationEvent(p.config.plugins, e))
+	}
+
+	if err := p.worker(apiKey).addEvents(apiKey, events); err != nil {
+		return err
+	}
+
+	for _, dest := range dests {
+		deliverEvent(ctx, p.config.plugins, dest)
+	}
+
+	return nil
+}
+
+// Map maps users for the tenant of apiKey, see Client.Map.
+func (p *Pool) Map(apiKey string, mappings ...*UserMapping) error {
+	if apiKey == "" {
+		return ErrMissingAPIKey
+	}
+
+	for _, mapping := range mappings {
+		if err := mapping.validate(); err != nil {
+			return err
+		}
+	}
+
+	return p.worker(apiKey).addMappings(apiKey, mappings)
+}
+
+// Tenants returns the number of tenants of the pool.
+func (p *Pool) Tenants() int {
+	n := 0
+
+	for _, w := range p.workers {
+		w.mtx.Lock()
+		n += len(w.tenants)
+		w.mtx.Unlock()
+	}
+
+	return n
+}
+
+// Close flushes the buffered events of the tenants and stops the workers.
+func (p *Pool) Close() (err error) {
+	defer func() {
+		// See client.Close.
+		if recover() != nil {
+			err = ErrClosed
+		}
+	}()
+
+	close(p.quitCh)
+
+	for _, w := range p.workers {
+		<-w.shutdownCh
+	}
+
+	return
+}
+
+func (p *Pool) worker(apiKey string) *poolWorker {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(apiKey))
+
+	return p.workers[h.Sum32()%uint32(len(p.workers))]
+}
+
+// tenant is the client of an API key of a pool, its loop is the one of its worker.
+type tenant struct {
+	*client
+
+	// lastSeen is the time of the last event or mapping, guarded by the mutex of the worker.
+	lastSeen time.Time
+}
+
+// buffered returns the number of events and mappings waiting for a flush.
+func (t *tenant) buffered() int {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	return len(t.events) + len(t.mappings)
+}
+
+type poolWorker struct {
+	pool       *Pool
+	mtx        sync.Mutex
+	tenants    map[string]*tenant
+	closed     bool
+	flushCh    chan struct{}
+	shutdownCh chan struct{}
+	retryTimer clock.Timer
+	retryAt    time.Time
+}
+
+// tenant returns the tenant of apiKey, created when missing, it must be called with the lock held.
+func (w *poolWorker) tenant(apiKey string) *tenant {
+	t, ok := w.tenants[apiKey]
+	if !ok {
+		c := newClient(apiKey, w.pool.opts...)
+		c.httpClient = w.pool.config.httpClient
+		c.flushCh = w.flushCh
+
+		t = &tenant{
+			client: c,
+		}
+
+		w.tenants[apiKey] = t
+	}
+
+	t.lastSeen = w.pool.config.clock.Now()
+
+	return t
+}
+
+func (w *poolWorker) addEvents(apiKey string, events []*Event) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.closed {
+		return ErrClosed
+	}
+
+	t := w.tenant(apiKey)
+
+	if t.buffered()+len(events) > w.pool.quota {
+		return ErrQuotaExceeded
+	}
+
+	t.mtx.Lock()
+	t.events = append(t.events, events...)
+	full := len(t.events) >= t.batchSize
+	t.mtx.Unlock()
+
+	if full {
+		w.signal()
+	}
+
+	return nil
+}
+
+func (w *poolWorker) addMappings(apiKey string, mappings []*UserMapping) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.closed {
+		return ErrClosed
+	}
+
+	t := w.tenant(apiKey)
+
+	if t.buffered()+len(mappings) > w.pool.quota {
+		return ErrQuotaExceeded
+	}
+
+	for _, mapping := range mappings {
+		t.addMapping(mapping)
+	}
+
+	return nil
+}
+
+// signal requests a flush without blocking.
+func (w *poolWorker) signal() {
+	select {
+	case w.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+func (w *poolWorker) snapshot() []*tenant {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	tenants := make([]*tenant, 0, len(w.tenants))
+
+	for _, t := range w.tenants {
+		tenants = append(tenants, t)
+	}
+
+	return tenants
+}
+
+func (w *poolWorker) loop() {
+	defer close(w.shutdownCh)
+
+	tick := w.pool.config.clock.NewTicker(w.pool.config.interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-w.flushCh:
+			w.flush()
+
+		case <-w.retryC():
+			w.retryDue()
+
+		case <-tick.C():
+			w.flush()
+			w.evict()
+
+		case <-w.pool.quitCh:
+			w.drain()
+
+			return
+		}
+	}
+}
+
+// retryC returns the channel of the timer of the earliest retry of the
+// tenants, nil when no retry is pending. The retries are only scheduled by
+// the loop, through flush and retryDue.
+func (w *poolWorker) retryC() <-chan time.Time {
+	var next time.Time
+
+	for _, t := range w.snapshot() {
+		if len(t.retries) > 0 && (next.IsZero() || t.retries[0].at.Before(next)) {
+			next = t.retries[0].at
+		}
+	}
+
+	if w.retryTimer != nil && !next.Equal(w.retryAt) {
+		w.retryTimer.Stop()
+		w.retryTimer = nil
+	}
+
+	if next.IsZero() {
+		return nil
+	}
+
+	if w.retryTimer == nil {
+		w.retryAt = next
+		w.retryTimer = w.pool.config.clock.NewTimer(next.Sub(w.pool.config.clock.Now()))
+	}
+
+	return w.retryTimer.C()
+}
+
+// retryDue sends the due retries of the tenants.
+func (w *poolWorker) retryDue() {
+	w.retryTimer = nil
+
+	for _, t := range w.snapshot() {
+		t.retryDue()
+	}
+}
+
+// flush sends a batch of each tenant.
+func (w *poolWorker) flush() {
+	for _, t := range w.snapshot() {
+		if err := t.flush(); err != nil {
+			log.Error().Err(err).Msg("Amplitude pool flush failed")
+		}
+	}
+}
+
+// evict removes the tenants idle for idleTimeout with nothing left to send.
+func (w *poolWorker) evict() {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	now := w.pool.config.clock.Now()
+
+	for key, t := range w.tenants {
+		if now.Sub(t.lastSeen) < w.pool.idleTimeout || len(t.retries) > 0 || t.buffered() > 0 {
+			continue
+		}
+
+		delete(w.tenants, key)
+	}
+}
+
+// drain rejects the new events then sends everything buffered.
+func (w *poolWorker) drain() {
+	w.mtx.Lock()
+	w.closed = true
+	w.mtx.Unlock()
+
+	if w.retryTimer != nil {
+		w.retryTimer.Stop()
+		w.retryTimer = nil
+	}
+
+	for _, t := range w.snapshot() {
+		for t.buffered() > 0 {
+			if err := t.flush(); err != nil {
+				log.Error().Err(err).Msg("Amplitude pool flush failed")
+			}
+		}
+
+		t.drainRetries()
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+// tenantServer records the event types received by API key.
+type tenantServer struct {
+	*httptest.Server
+
+	mtx      sync.Mutex
+	events   map[string][]string
+	mappings map[string]int
+}
+
+func newTenantServer(t *testing.T) *tenantServer {
+	s := &tenantServer{
+		events:   map[string][]string{},
+		mappings: map[string]int{},
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+
+		if r.URL.Path == "/usermap" {
+			assert.NoError(t, r.ParseForm())
+
+			var mappings []*UserMapping
+
+			assert.NoError(t, json.Unmarshal([]byte(r.PostForm.Get("mapping")), &mappings))
+
+			s.mappings[r.PostForm.Get("api_key")] += len(mappings)
+
+			return
+		}
+
+		payload := &RequestPayload{}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(payload))
+
+		for _, event := range payload.Events {
+			s.events[payload.APIKey] = append(s.events[payload.APIKey], event.EventType)
+		}
+	}))
+
+	return s
+}
+
+func (s *tenantServer) received(apiKey string) []string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return append([]string(nil), s.events[apiKey]...)
+}
+
+func (s *tenantServer) options(opts ...Option) PoolOption {
+	return WithTenantOptions(append([]Option{WithURL(s.URL), WithUserMapURL(s.URL + "/usermap")}, opts...)...)
+}
+
+func TestPool(t *testing.T) {
+	srv := newTenantServer(t)
+	defer srv.Close()
+
+	p := NewPool(WithPoolWorkers(2), srv.options())
+
+	assert.NoError(t, p.Enqueue("tenant-1", &Event{EventType: "user.created", UserID: "user-1"}))
+	assert.NoError(t, p.Enqueue("tenant-2", &Event{EventType: "song.played", UserID: "user-2"}))
+	assert.NoError(t, p.Enqueue("tenant-1", &Event{EventType: "user.deleted", UserID: "user-1"}))
+	assert.NoError(t, p.Map("tenant-2", &UserMapping{UserID: "user-2", GlobalUserID: "global-2"}))
+
+	assert.Equal(t, 2, p.Tenants())
+
+	assert.NoError(t, p.Close())
+
+	assert.Equal(t, []string{"user.created", "user.deleted"}, srv.received("tenant-1"))
+	assert.Equal(t, []string{"song.played"}, srv.received("tenant-2"))
+	assert.Equal(t, map[string]int{"tenant-2": 1}, srv.mappings)
+
+	assert.ErrorIs(t, p.Close(), ErrClosed)
+	assert.ErrorIs(t, p.Enqueue("tenant-1", &Event{EventType: "user.created"}), ErrClosed)
+	assert.ErrorIs(t, p.Map("tenant-1", &UserMapping{UserID: "user-1", GlobalUserID: "global-1"}), ErrClosed)
+}
+
+func TestWithPoolWorkers(t *testing.T) {
+	p := &Pool{}
+
+	WithPoolWorkers(3)(p)
+
+	assert.Len(t, p.workers, 3)
+
+	for _, workers := range []int{0, -1} {
+		WithPoolWorkers(workers)(p)
+
+		assert.Len(t, p.workers, 1)
+	}
+}
+
+func TestPoolErrors(t *testing.T) {
+	p := NewPool(WithTenantOptions(WithDryRun(io.Discard, DryRunNDJSON)))
+	defer p.Close()
+
+	assert.ErrorIs(t, p.Enqueue("", &Event{EventType: "user.created"}), ErrMissingAPIKey)
+	assert.ErrorIs(t, p.Map("", &UserMapping{UserID: "user-1", GlobalUserID: "global-1"}), ErrMissingAPIKey)
+	assert.ErrorIs(t, p.Map("tenant-1", &UserMapping{UserID: "user-1"}), ErrInvalidMapping)
+
+	assert.Equal(t, 0, p.Tenants())
+}
+
+func TestPoolQuota(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1643367217, 0))
+
+	p := NewPool(
+		WithPoolWorkers(1),
+		WithTenantQuota(2),
+		WithTenantOptions(WithDryRun(io.Discard, DryRunNDJSON), WithClock(fake)),
+	)
+	defer p.Close()
+
+	assert.NoError(t, p.Enqueue("tenant-1", &Event{EventType: "user.created"}))
+	assert.NoError(t, p.Map("tenant-1", &UserMapping{UserID: "user-1", GlobalUserID: "global-1"}))
+	assert.ErrorIs(t, p.Enqueue("tenant-1", &Event{EventType: "user.created"}), ErrQuotaExceeded)
+	assert.ErrorIs(t, p.Map("tenant-1", &UserMapping{UserID: "user-1", GlobalUserID: "global-1"}), ErrQuotaExceeded)
+
+	// The quota is per tenant.
+	assert.NoError(t, p.Enqueue("tenant-2", &Event{EventType: "user.created"}))
+}
+
+func TestPoolFlushBatch(t *testing.T) {
+	srv := newTenantServer(t)
+	defer srv.Close()
+
+	fake := clock.NewFake(time.Unix(1643367217, 0))
+
+	p := NewPool(WithPoolWorkers(1), srv.options(WithBatchSize(2), WithClock(fake)))
+	defer p.Close()
+
+	assert.NoError(t, p.Enqueue("tenant-1", &Event{EventType: "user.created"}))
+	assert.NoError(t, p.Enqueue("tenant-1", &Event{EventType: "user.updated"}))
+
+	// A full batch is sent without waiting for the interval.
+	assert.Eventually(t, func() bool {
+		return len(srv.received("tenant-1")) == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestPoolIdleTimeout(t *testing.T) {
+	srv := newTenantServer(t)
+	defer srv.Close()
+
+	fake := clock.NewFake(time.Unix(1643367217, 0))
+
+	p := NewPool(
+		WithPoolWorkers(1),
+		WithTenantIdleTimeout(time.Minute),
+		srv.options(WithInterval(time.Second), WithClock(fake)),
+	)
+	defer p.Close()
+
+	assert.NoError(t, p.Enqueue("tenant-1", &Event{EventType: "user.created"}))
+
+	fake.BlockUntil(1)
+	fake.Advance(time.Second)
+
+	assert.Eventually(t, func() bool {
+		return len(srv.received("tenant-1")) == 1
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, 1, p.Tenants())
+
+	fake.Advance(time.Minute)
+
+	assert.Eventually(t, func() bool {
+		return p.Tenants() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestPoolRetry(t *testing.T) {
+	var (
+		mtx      sync.Mutex
+		attempts int
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		attempts++
+
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	done := make(chan struct{})
+
+	fake := clock.NewFake(time.Unix(1643367217, 0))
+
+	p := NewPool(WithPoolWorkers(1), WithTenantOptions(
+		WithURL(ts.URL),
+		WithInterval(time.Minute),
+		WithBatchSize(1),
+		WithRetryInterval(time.Second*5),
+		WithClock(fake),
+		WithCallback(func(payload *Payload, err error) {
+			defer close(done)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 2, payload.Attempts)
+		}),
+	))
+	defer p.Close()
+
+	assert.NoError(t, p.Enqueue("tenant-1", &Event{EventType: "user.created"}))
+
+	// The ticker and the timer of the retry.
+	fake.BlockUntil(2)
+
+	// The retry is sent after its backoff, before the next tick.
+	fake.Advance(time.Second * 5)
+
+	<-done
+
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	assert.Equal(t, 2, attempts)
+}
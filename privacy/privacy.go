@@ -0,0 +1,210 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package privacy implements a client of the Amplitude User Privacy API.
+// see: https://amplitude.com/docs/apis/analytics/user-privacy
+package privacy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/internal/api"
+)
+
+const (
+	StandardEndpoint    = api.StandardEndpoint
+	EUResidencyEndpoint = api.EUResidencyEndpoint
+)
+
+// DayFormat is the layout of the days of the User Privacy API.
+const DayFormat = "2006-01-02"
+
+const deletionsPath = "/api/2/deletions/users"
+
+// Statuses of deletion jobs.
+const (
+	StatusStaging   = "staging"
+	StatusSubmitted = "submitted"
+	StatusDone      = "done"
+)
+
+// DeletionRequest struct.
+type DeletionRequest struct {
+	AmplitudeIDs []int64  `json:"amplitude_ids,omitempty"`
+	UserIDs      []string `json:"user_ids,omitempty"`
+
+	// Requester is the email address of the person requesting the deletion.
+	Requester string `json:"requester,omitempty"`
+
+	// IgnoreInvalidID ignores the invalid IDs instead of failing the request.
+	IgnoreInvalidID bool `json:"-"`
+
+	// DeleteFromOrg deletes the users from all the projects of the organization.
+	DeleteFromOrg bool `json:"-"`
+
+	// IncludeMappedUserIDs deletes the user IDs mapped to the given ones.
+	IncludeMappedUserIDs bool `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the API expects "True" and "False" strings.
+func (r *DeletionRequest) MarshalJSON() ([]byte, error) {
+	type alias DeletionRequest
+
+	b, err := json.Marshal(&struct {
+		*alias
+		IgnoreInvalidID      string `json:"ignore_invalid_id"`
+		DeleteFromOrg        string `json:"delete_from_org"`
+		IncludeMappedUserIDs string `json:"include_mapped_user_ids"`
+	}{
+		alias:                (*alias)(r),
+		IgnoreInvalidID:      pythonBool(r.IgnoreInvalidID),
+		DeleteFromOrg:        pythonBool(r.DeleteFromOrg),
+		IncludeMappedUserIDs: pythonBool(r.IncludeMappedUserIDs),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json encode deletion request failed: %w", err)
+	}
+
+	return b, nil
+}
+
+func pythonBool(b bool) string {
+	if b {
+		return "True"
+	}
+
+	return "False"
+}
+
+// DeletionUser is a user of a deletion job.
+type DeletionUser struct {
+	AmplitudeID    int64  `json:"amplitude_id"`
+	UserID         string `json:"user_id,omitempty"`
+	Requester      string `json:"requester,omitempty"`
+	RequestedOnDay string `json:"requested_on_day,omitempty"`
+}
+
+// DeletionJob is a batch of deletions run on Day.
+type DeletionJob struct {
+	Day                 string          `json:"day"`
+	Status              string          `json:"status"`
+	AmplitudeIDs        []*DeletionUser `json:"amplitude_ids"`
+	ActiveScrubDoneDate string          `json:"active_scrub_done_date,omitempty"`
+}
+
+// Client of the User Privacy API.
+type Client struct {
+	api *api.Client
+}
+
+// New User Privacy API client authenticated with the API key and secret key of the project.
+func New(apiKey string, secretKey string, opts ...Option) *Client {
+	c := &Client{
+		api: api.New(StandardEndpoint),
+	}
+
+	c.api.Authorize = api.BasicAuth(apiKey, secretKey)
+
+	api.Apply(c, c.api, opts...)
+
+	return c
+}
+
+// Delete requests the deletion of users by user ID or Amplitude ID.
+func (c *Client) Delete(ctx context.Context, req *DeletionRequest) ([]*DeletionJob, error) {
+	r, err := api.NewJSONRequest(http.MethodPost, deletionsPath, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.api.Do(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	defer api.CloseBody(resp)
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body failed: %w", err)
+	}
+
+	// The API responds with a job, or a list of jobs when the users are
+	// scheduled on several days.
+	var jobs []*DeletionJob
+
+	if b = bytes.TrimSpace(b); len(b) > 0 && b[0] != '[' {
+		job := &DeletionJob{}
+
+		if err := json.Unmarshal(b, job); err != nil {
+			return nil, fmt.Errorf("json decode response failed: %w", err)
+		}
+
+		return []*DeletionJob{job}, nil
+	}
+
+	if err := json.Unmarshal(b, &jobs); err != nil {
+		return nil, fmt.Errorf("json decode response failed: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// DeleteUserIDs requests the deletion of users by user ID.
+func (c *Client) DeleteUserIDs(ctx context.Context, requester string, userIDs ...string) ([]*DeletionJob, error) {
+	return c.Delete(ctx, &DeletionRequest{
+		UserIDs:   userIDs,
+		Requester: requester,
+	})
+}
+
+// DeleteAmplitudeIDs requests the deletion of users by Amplitude ID.
+func (c *Client) DeleteAmplitudeIDs(ctx context.Context, requester string, amplitudeIDs ...int64) ([]*DeletionJob, error) {
+	return c.Delete(ctx, &DeletionRequest{
+		AmplitudeIDs: amplitudeIDs,
+		Requester:    requester,
+	})
+}
+
+// List returns the deletion jobs scheduled between start and end days, included.
+func (c *Client) List(ctx context.Context, start time.Time, end time.Time) ([]*DeletionJob, error) {
+	var jobs []*DeletionJob
+
+	err := c.api.JSON(ctx, &api.Request{
+		Method: http.MethodGet,
+		Path:   deletionsPath,
+		Query: url.Values{
+			"start_day": {start.Format(DayFormat)},
+			"end_day":   {end.Format(DayFormat)},
+		},
+	}, &jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// Cancel removes a user, by user ID or Amplitude ID, from the deletion job of day.
+// Only jobs with the staging status can be canceled.
+func (c *Client) Cancel(ctx context.Context, id string, day time.Time) ([]*DeletionUser, error) {
+	var users []*DeletionUser
+
+	err := c.api.JSON(ctx, &api.Request{
+		Method: http.MethodDelete,
+		Path:   deletionsPath + "/" + url.PathEscape(id) + "/" + day.Format(DayFormat),
+	}, &users)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package privacy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *Client) {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "key", user)
+		assert.Equal(t, "secret", pass)
+
+		handler(w, r)
+	}))
+
+	return ts, New("key", "secret", WithURL(ts.URL), WithRetryInterval(time.Millisecond))
+}
+
+func TestClientDelete(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/2/deletions/users", r.URL.Path)
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		assert.JSONEq(t, `{"user_ids":["user-1","user-2"],"requester":"dpo@example.com","ignore_invalid_id":"False","delete_from_org":"False","include_mapped_user_ids":"False"}`, string(b))
+
+		w.Write([]byte(`{"day":"2026-10-19","status":"staging","amplitude_ids":[{"amplitude_id":123,"user_id":"user-1","requester":"dpo@example.com","requested_on_day":"2026-10-19"}]}`))
+	})
+	defer ts.Close()
+
+	jobs, err := c.DeleteUserIDs(context.Background(), "dpo@example.com", "user-1", "user-2")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*DeletionJob{{
+		Day:    "2026-10-19",
+		Status: StatusStaging,
+		AmplitudeIDs: []*DeletionUser{{
+			AmplitudeID:    123,
+			UserID:         "user-1",
+			Requester:      "dpo@example.com",
+			RequestedOnDay: "2026-10-19",
+		}},
+	}}, jobs)
+}
+
+func TestClientDeleteAmplitudeIDs(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		assert.JSONEq(t, `{"amplitude_ids":[123],"requester":"dpo@example.com","ignore_invalid_id":"False","delete_from_org":"False","include_mapped_user_ids":"False"}`, string(b))
+
+		w.Write([]byte(`[{"day":"2026-10-19","status":"staging","amplitude_ids":[{"amplitude_id":123}]},{"day":"2026-10-20","status":"staging","amplitude_ids":[]}]`))
+	})
+	defer ts.Close()
+
+	jobs, err := c.DeleteAmplitudeIDs(context.Background(), "dpo@example.com", 123)
+	assert.NoError(t, err)
+
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, int64(123), jobs[0].AmplitudeIDs[0].AmplitudeID)
+	assert.Equal(t, "2026-10-20", jobs[1].Day)
+}
+
+func TestClientDeleteRequestOptions(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		assert.JSONEq(t, `{"user_ids":["user-1"],"ignore_invalid_id":"True","delete_from_org":"True","include_mapped_user_ids":"True"}`, string(b))
+
+		w.Write([]byte(`{`))
+	})
+	defer ts.Close()
+
+	_, err := c.Delete(context.Background(), &DeletionRequest{
+		UserIDs:              []string{"user-1"},
+		IgnoreInvalidID:      true,
+		DeleteFromOrg:        true,
+		IncludeMappedUserIDs: true,
+	})
+	assert.Error(t, err)
+}
+
+func TestClientDeleteError(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid user ids", http.StatusBadRequest)
+	})
+	defer ts.Close()
+
+	_, err := c.DeleteUserIDs(context.Background(), "dpo@example.com", "user-1")
+
+	var apiErr *amplitude.APIError
+
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "invalid user ids", apiErr.Message)
+}
+
+func TestClientList(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/2/deletions/users", r.URL.Path)
+		assert.Equal(t, "2026-10-01", r.URL.Query().Get("start_day"))
+		assert.Equal(t, "2026-10-31", r.URL.Query().Get("end_day"))
+
+		w.Write([]byte(`[{"day":"2026-10-19","status":"done","amplitude_ids":[{"amplitude_id":123}],"active_scrub_done_date":"2026-10-20"}]`))
+	})
+	defer ts.Close()
+
+	jobs, err := c.List(
+		context.Background(),
+		time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC),
+	)
+	assert.NoError(t, err)
+
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, StatusDone, jobs[0].Status)
+	assert.Equal(t, "2026-10-20", jobs[0].ActiveScrubDoneDate)
+}
+
+func TestClientCancel(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/api/2/deletions/users/user-1/2026-10-19", r.URL.Path)
+
+		w.Write([]byte(`[{"amplitude_id":123,"requester":"dpo@example.com","requested_on_day":"2026-10-18"}]`))
+	})
+	defer ts.Close()
+
+	users, err := c.Cancel(context.Background(), "user-1", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*DeletionUser{{
+		AmplitudeID:    123,
+		Requester:      "dpo@example.com",
+		RequestedOnDay: "2026-10-18",
+	}}, users)
+}
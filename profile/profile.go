@@ -0,0 +1,238 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package profile implements a client of the Amplitude User Profile API.
+// see: https://amplitude.com/docs/apis/analytics/user-profile
+package profile
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/internal/api"
+	"github.com/euskadi31/go-amplitude/internal/cache"
+)
+
+const (
+	StandardEndpoint    = "https://profile-api.amplitude.com"
+	EUResidencyEndpoint = "https://profile-api.eu.amplitude.com"
+)
+
+// computationPrefix is the prefix of the computations in the user properties.
+const computationPrefix = "computed-"
+
+// ErrMissingID is returned when a request has no user ID nor device ID.
+var ErrMissingID = errors.New("user id or device id is required")
+
+// Request selects the user and the data returned.
+type Request struct {
+	UserID   string
+	DeviceID string
+
+	// Properties returns the user properties.
+	Properties bool
+
+	// CohortIDs returns the IDs of the cohorts the user belongs to.
+	CohortIDs bool
+
+	// Computations returns the computed properties.
+	Computations bool
+
+	// RecommendationIDs returns the recommendations of these IDs.
+	RecommendationIDs []string
+}
+
+func (r *Request) query() url.Values {
+	q := url.Values{}
+
+	if r.UserID != "" {
+		q.Set("user_id", r.UserID)
+	}
+
+	if r.DeviceID != "" {
+		q.Set("device_id", r.DeviceID)
+	}
+
+	if r.Properties {
+		q.Set("get_amp_props", "true")
+	}
+
+	if r.CohortIDs {
+		q.Set("get_cohort_ids", "true")
+	}
+
+	if r.Computations {
+		q.Set("get_computations", "true")
+	}
+
+	if len(r.RecommendationIDs) > 0 {
+		q.Set("get_recs", "true")
+		q.Set("rec_id", strings.Join(r.RecommendationIDs, ","))
+	}
+
+	return q
+}
+
+// Recommendation struct.
+type Recommendation struct {
+	ID                   string   `json:"rec_id"`
+	ChildID              string   `json:"child_rec_id"`
+	Items                []string `json:"items"`
+	IsControl            bool     `json:"is_control"`
+	RecommendationSource string   `json:"recommendation_source"`
+	LastUpdated          int64    `json:"last_updated"`
+}
+
+// Profile of a user.
+type Profile struct {
+	UserID          string                 `json:"user_id"`
+	DeviceID        string                 `json:"device_id"`
+	Properties      map[string]interface{} `json:"amp_props"`
+	CohortIDs       []string               `json:"cohort_ids"`
+	Recommendations []*Recommendation      `json:"recommendations"`
+
+	// Computations are the computed properties, by name without the "computed-" prefix.
+	Computations map[string]interface{} `json:"-"`
+}
+
+// Client of the User Profile API.
+type Client struct {
+	api       *api.Client
+	cacheTTL  time.Duration
+	cacheSize int
+	cache     *cache.Cache[*Profile]
+}
+
+// New User Profile API client authenticated with the secret key of the project.
+// Profiles are cached for a minute by default, see WithCacheTTL.
+func New(secretKey string, opts ...Option) *Client {
+	c := &Client{
+		api:       api.New(StandardEndpoint),
+		cacheTTL:  time.Minute,
+		cacheSize: 10000,
+	}
+
+	c.api.Timeout = time.Second * 2
+	c.api.Authorize = api.APIKeyAuth(secretKey)
+
+	api.Apply(c, c.api, opts...)
+
+	if c.cacheTTL > 0 {
+		c.cache = cache.New[*Profile](c.cacheTTL, c.cacheSize)
+	}
+
+	return c
+}
+
+// Get returns the profile of a user, from the cache when possible.
+// Cached profiles are shared and must not be modified.
+func (c *Client) Get(ctx context.Context, req *Request) (*Profile, error) {
+	if req.UserID == "" && req.DeviceID == "" {
+		return nil, ErrMissingID
+	}
+
+	q := req.query()
+	key := q.Encode()
+
+	if c.cache != nil {
+		if profile, ok := c.cache.Get(key); ok {
+			return profile, nil
+		}
+	}
+
+	resp := &struct {
+		UserData *Profile `json:"userData"`
+	}{}
+
+	if err := c.api.JSON(ctx, &api.Request{
+		Method: http.MethodGet,
+		Path:   "/v1/userprofile",
+		Query:  q,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	profile := resp.UserData
+	if profile == nil {
+		profile = &Profile{}
+	}
+
+	extractComputations(profile)
+
+	if c.cache != nil {
+		c.cache.Set(key, profile)
+	}
+
+	return profile, nil
+}
+
+// Invalidate removes the cached profiles of req.
+func (c *Client) Invalidate(req *Request) {
+	if c.cache != nil {
+		c.cache.Delete(req.query().Encode())
+	}
+}
+
+func extractComputations(profile *Profile) {
+	for key, value := range profile.Properties {
+		if !strings.HasPrefix(key, computationPrefix) {
+			continue
+		}
+
+		if profile.Computations == nil {
+			profile.Computations = map[string]interface{}{}
+		}
+
+		profile.Computations[strings.TrimPrefix(key, computationPrefix)] = value
+
+		delete(profile.Properties, key)
+	}
+}
+
+// CohortMember reports whether the user belongs to the cohort.
+func (p *Profile) CohortMember(cohortID string) bool {
+	for _, id := range p.CohortIDs {
+		if id == cohortID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Recommendation returns the recommendation of id.
+func (p *Profile) Recommendation(id string) (*Recommendation, bool) {
+	for _, rec := range p.Recommendations {
+		if rec.ID == id {
+			return rec, true
+		}
+	}
+
+	return nil, false
+}
+
+// StringProperty returns the value of a string user property.
+func (p *Profile) StringProperty(name string) (string, bool) {
+	v, ok := p.Properties[name].(string)
+
+	return v, ok
+}
+
+// NumberProperty returns the value of a number user property.
+func (p *Profile) NumberProperty(name string) (float64, bool) {
+	v, ok := p.Properties[name].(float64)
+
+	return v, ok
+}
+
+// BoolProperty returns the value of a boolean user property.
+func (p *Profile) BoolProperty(name string) (bool, bool) {
+	v, ok := p.Properties[name].(bool)
+
+	return v, ok
+}
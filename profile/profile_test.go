@@ -0,0 +1,163 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package profile
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const profileResponse = `{
+	"userData": {
+		"user_id": "user-1",
+		"device_id": "device-1",
+		"amp_props": {
+			"plan": "premium",
+			"age": 37,
+			"verified": true,
+			"computed-lifetime_value": 120.5
+		},
+		"cohort_ids": ["abc", "def"],
+		"recommendations": [
+			{
+				"rec_id": "rec-1",
+				"child_rec_id": "rec-1-child",
+				"items": ["song-1", "song-2"],
+				"is_control": false,
+				"recommendation_source": "model",
+				"last_updated": 1792404000
+			}
+		]
+	}
+}`
+
+func TestClientGet(t *testing.T) {
+	hits := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+
+		assert.Equal(t, "Api-Key secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "/v1/userprofile", r.URL.Path)
+
+		q := r.URL.Query()
+
+		assert.Equal(t, "user-1", q.Get("user_id"))
+		assert.Equal(t, "device-1", q.Get("device_id"))
+		assert.Equal(t, "true", q.Get("get_amp_props"))
+		assert.Equal(t, "true", q.Get("get_cohort_ids"))
+		assert.Equal(t, "true", q.Get("get_computations"))
+		assert.Equal(t, "true", q.Get("get_recs"))
+		assert.Equal(t, "rec-1,rec-2", q.Get("rec_id"))
+
+		w.Write([]byte(profileResponse))
+	}))
+	defer ts.Close()
+
+	c := New("secret", WithURL(ts.URL))
+
+	req := &Request{
+		UserID:            "user-1",
+		DeviceID:          "device-1",
+		Properties:        true,
+		CohortIDs:         true,
+		Computations:      true,
+		RecommendationIDs: []string{"rec-1", "rec-2"},
+	}
+
+	profile, err := c.Get(context.Background(), req)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "user-1", profile.UserID)
+	assert.Equal(t, "device-1", profile.DeviceID)
+	assert.Equal(t, map[string]interface{}{
+		"plan":     "premium",
+		"age":      float64(37),
+		"verified": true,
+	}, profile.Properties)
+	assert.Equal(t, map[string]interface{}{
+		"lifetime_value": 120.5,
+	}, profile.Computations)
+
+	plan, ok := profile.StringProperty("plan")
+	assert.True(t, ok)
+	assert.Equal(t, "premium", plan)
+
+	age, ok := profile.NumberProperty("age")
+	assert.True(t, ok)
+	assert.Equal(t, float64(37), age)
+
+	verified, ok := profile.BoolProperty("verified")
+	assert.True(t, ok)
+	assert.True(t, verified)
+
+	_, ok = profile.StringProperty("age")
+	assert.False(t, ok)
+
+	assert.True(t, profile.CohortMember("abc"))
+	assert.False(t, profile.CohortMember("xyz"))
+
+	rec, ok := profile.Recommendation("rec-1")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"song-1", "song-2"}, rec.Items)
+	assert.Equal(t, "model", rec.RecommendationSource)
+
+	_, ok = profile.Recommendation("rec-2")
+	assert.False(t, ok)
+
+	cached, err := c.Get(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Same(t, profile, cached)
+	assert.Equal(t, 1, hits)
+
+	c.Invalidate(req)
+
+	_, err = c.Get(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, hits)
+}
+
+func TestClientGetWithoutCache(t *testing.T) {
+	hits := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	c := New("secret", WithURL(ts.URL), WithCacheTTL(0))
+
+	for i := 0; i < 2; i++ {
+		profile, err := c.Get(context.Background(), &Request{DeviceID: "device-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, &Profile{}, profile)
+	}
+
+	assert.Equal(t, 2, hits)
+
+	c.Invalidate(&Request{DeviceID: "device-1"})
+}
+
+func TestClientGetError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "user not found", http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	c := New("secret", WithURL(ts.URL), WithRetryInterval(time.Millisecond))
+
+	_, err := c.Get(context.Background(), &Request{UserID: "user-1"})
+	assert.EqualError(t, err, "400: user not found")
+
+	_, err = c.Get(context.Background(), &Request{})
+	assert.ErrorIs(t, err, ErrMissingID)
+}
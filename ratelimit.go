@@ -0,0 +1,282 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/clock"
+)
+
+// RateLimitAction defines what happens to the events over the limit.
+type RateLimitAction int
+
+const (
+	// RateLimitDrop drops the events over the limit.
+	RateLimitDrop RateLimitAction = iota
+
+	// RateLimitDelay blocks Enqueue until the event is within the limit,
+	// the events which would wait more than MaxDelay are dropped.
+	RateLimitDelay
+
+	// RateLimitSample keeps one in Sample events over the limit and drops the others.
+	RateLimitSample
+)
+
+// rateLimitSweepInterval is the interval of the removal of the full buckets.
+const rateLimitSweepInterval = time.Minute
+
+// RateLimitConfig struct.
+type RateLimitConfig struct {
+	// Rate is the number of events per second allowed for a device and for a user,
+	// the limiter lets everything through when it is not positive.
+	Rate float64
+
+	// Burst is the number of events allowed at once, Rate rounded up by default.
+	Burst int
+
+	Action RateLimitAction
+
+	// MaxDelay bounds the wait of RateLimitDelay, unbounded when zero.
+	MaxDelay time.Duration
+
+	// Sample is the sampling rate of RateLimitSample, 10 by default.
+	Sample int
+
+	// Clock of the buckets, the clock of the client by default, see WithClock.
+	Clock clock.Clock
+}
+
+// RateLimitStats counts the events over the limit.
+type RateLimitStats struct {
+	Delayed uint64
+	Dropped uint64
+	Sampled uint64
+}
+
+// tokenBucket of a device or a user.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	over   uint64
+}
+
+// RateLimiter is a before plugin limiting the rate of the events of each
+// device and each user with token buckets, keyed by DeviceID and UserID.
+// Amplitude throttles the devices and users above a rate of events, which
+// fails the whole batch.
+type RateLimiter struct {
+	config    RateLimitConfig
+	mtx       sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	stats     RateLimitStats
+
+	// clockSet is false until a clock is configured or given by a client.
+	clockSet bool
+}
+
+var _ Plugin = (*RateLimiter)(nil)
+
+// NewRateLimiter returns a RateLimiter, see WithRateLimiter.
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	if config.Burst <= 0 {
+		config.Burst = int(math.Max(1, math.Ceil(config.Rate)))
+	}
+
+	if config.Sample <= 0 {
+		config.Sample = 10
+	}
+
+	clockSet := config.Clock != nil
+
+	if !clockSet {
+		config.Clock = clock.Real()
+	}
+
+	return &RateLimiter{
+		config:    config,
+		buckets:   map[string]*tokenBucket{},
+		lastSweep: config.Clock.Now(),
+		clockSet:  clockSet,
+	}
+}
+
+// setClock sets the clock of the limiter when none is configured, the
+// limiter keeps the clock of the first client it is given to.
+func (l *RateLimiter) setClock(clk clock.Clock) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.clockSet {
+		return
+	}
+
+	l.config.Clock = clk
+	l.lastSweep = clk.Now()
+	l.clockSet = true
+}
+
+// Stats returns the counters of the events over the limit.
+func (l *RateLimiter) Stats() RateLimitStats {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	return l.stats
+}
+
+func (l *RateLimiter) Type() PluginType {
+	return PluginTypeBefore
+}
+
+func (l *RateLimiter) Execute(ctx context.Context, event *Event) ([]*Event, error) {
+	keys := make([]string, 0, 2)
+
+	if event.DeviceID != "" {
+		keys = append(keys, "device:"+event.DeviceID)
+	}
+
+	if event.UserID != "" {
+		keys = append(keys, "user:"+event.UserID)
+	}
+
+	if l.config.Rate <= 0 || len(keys) == 0 {
+		return []*Event{event}, nil
+	}
+
+	wait, clk, ok := l.take(keys)
+	if !ok {
+		return nil, nil
+	}
+
+	if wait > 0 {
+		timer := clk.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			return nil, fmt.Errorf("rate limit wait failed: %w", ctx.Err())
+		}
+	}
+
+	return []*Event{event}, nil
+}
+
+// take consumes a token of each bucket of keys and returns the time to wait
+// for them on the returned clock, or false when the event is dropped.
+func (l *RateLimiter) take(keys []string) (time.Duration, clock.Clock, bool) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	clk := l.config.Clock
+	now := clk.Now()
+
+	l.sweep(now)
+
+	buckets := make([]*tokenBucket, 0, len(keys))
+
+	var limited *tokenBucket
+
+	for _, key := range keys {
+		b := l.bucket(key, now)
+
+		if b.tokens < 1 && limited == nil {
+			limited = b
+		}
+
+		buckets = append(buckets, b)
+	}
+
+	if limited == nil {
+		for _, b := range buckets {
+			b.tokens--
+		}
+
+		return 0, clk, true
+	}
+
+	switch l.config.Action {
+	case RateLimitDelay:
+		var wait time.Duration
+
+		for _, b := range buckets {
+			if d := time.Duration((1 - b.tokens) / l.config.Rate * float64(time.Second)); d > wait {
+				wait = d
+			}
+		}
+
+		if l.config.MaxDelay > 0 && wait > l.config.MaxDelay {
+			l.stats.Dropped++
+
+			return 0, clk, false
+		}
+
+		// The tokens are reserved, the buckets go negative until refilled.
+		for _, b := range buckets {
+			b.tokens--
+		}
+
+		l.stats.Delayed++
+
+		return wait, clk, true
+
+	case RateLimitSample:
+		limited.over++
+
+		if (limited.over-1)%uint64(l.config.Sample) == 0 {
+			l.stats.Sampled++
+
+			return 0, clk, true
+		}
+
+	case RateLimitDrop:
+	}
+
+	l.stats.Dropped++
+
+	return 0, clk, false
+}
+
+// bucket returns the bucket of key refilled at now, it must be called with the lock held.
+func (l *RateLimiter) bucket(key string, now time.Time) *tokenBucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{
+			tokens: float64(l.config.Burst),
+			last:   now,
+		}
+
+		l.buckets[key] = b
+
+		return b
+	}
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(l.config.Burst), b.tokens+elapsed.Seconds()*l.config.Rate)
+		b.last = now
+	}
+
+	return b
+}
+
+// sweep removes the full buckets, which behave as new ones, it must be called with the lock held.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+
+	l.lastSweep = now
+
+	for key := range l.buckets {
+		if b := l.bucket(key, now); b.tokens >= float64(l.config.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
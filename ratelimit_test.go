@@ -0,0 +1,208 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func executeN(l *RateLimiter, event *Event, n int) int {
+	kept := 0
+
+	for i := 0; i < n; i++ {
+		events, err := l.Execute(context.Background(), event.Clone())
+		if err == nil && len(events) == 1 {
+			kept++
+		}
+	}
+
+	return kept
+}
+
+func TestRateLimiterDrop(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1643367217, 0))
+
+	l := NewRateLimiter(RateLimitConfig{
+		Rate:  2,
+		Clock: fake,
+	})
+
+	assert.Equal(t, PluginTypeBefore, l.Type())
+
+	device := &Event{DeviceID: "device-1"}
+
+	assert.Equal(t, 2, executeN(l, device, 5))
+
+	// The buckets are per device.
+	assert.Equal(t, 2, executeN(l, &Event{DeviceID: "device-2"}, 5))
+
+	// The events without ID are not limited.
+	assert.Equal(t, 5, executeN(l, &Event{}, 5))
+
+	fake.Advance(time.Millisecond * 500)
+
+	assert.Equal(t, 1, executeN(l, device, 5))
+
+	fake.Advance(time.Hour)
+
+	// The bucket is refilled up to the burst.
+	assert.Equal(t, 2, executeN(l, device, 5))
+
+	assert.Equal(t, RateLimitStats{Dropped: 13}, l.Stats())
+}
+
+func TestRateLimiterUserAndDevice(t *testing.T) {
+	l := NewRateLimiter(RateLimitConfig{
+		Rate:  1,
+		Burst: 2,
+		Clock: clock.NewFake(time.Unix(1643367217, 0)),
+	})
+
+	assert.Equal(t, 2, executeN(l, &Event{UserID: "user-1", DeviceID: "device-1"}, 3))
+
+	// The user is limited on its other devices.
+	assert.Equal(t, 0, executeN(l, &Event{UserID: "user-1", DeviceID: "device-2"}, 1))
+
+	// The device is limited for other users.
+	assert.Equal(t, 0, executeN(l, &Event{UserID: "user-2", DeviceID: "device-1"}, 1))
+
+	assert.Equal(t, 2, executeN(l, &Event{UserID: "user-2", DeviceID: "device-2"}, 1)+executeN(l, &Event{UserID: "user-2"}, 1))
+}
+
+func TestRateLimiterSample(t *testing.T) {
+	l := NewRateLimiter(RateLimitConfig{
+		Rate:   1,
+		Action: RateLimitSample,
+		Sample: 3,
+		Clock:  clock.NewFake(time.Unix(1643367217, 0)),
+	})
+
+	// 1 within the limit, then 1 in 3 of the 9 others.
+	assert.Equal(t, 4, executeN(l, &Event{DeviceID: "device-1"}, 10))
+	assert.Equal(t, RateLimitStats{Dropped: 6, Sampled: 3}, l.Stats())
+}
+
+func TestRateLimiterDelay(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1643367217, 0))
+
+	l := NewRateLimiter(RateLimitConfig{
+		Rate:     2,
+		Action:   RateLimitDelay,
+		MaxDelay: time.Second,
+		Clock:    fake,
+	})
+
+	device := &Event{DeviceID: "device-1"}
+
+	assert.Equal(t, 2, executeN(l, device, 2))
+
+	done := make(chan int)
+
+	go func() {
+		done <- executeN(l, device, 1)
+	}()
+
+	fake.BlockUntil(1)
+
+	select {
+	case <-done:
+		t.Fatal("the event was not delayed")
+	default:
+	}
+
+	// Another event would wait a second.
+	go func() {
+		done <- executeN(l, device, 1)
+	}()
+
+	fake.BlockUntil(2)
+
+	// A third one would wait over MaxDelay.
+	assert.Equal(t, 0, executeN(l, device, 1))
+
+	fake.Advance(time.Millisecond * 500)
+	assert.Equal(t, 1, <-done)
+
+	fake.Advance(time.Millisecond * 500)
+	assert.Equal(t, 1, <-done)
+
+	assert.Equal(t, RateLimitStats{Delayed: 2, Dropped: 1}, l.Stats())
+}
+
+func TestRateLimiterDelayCanceled(t *testing.T) {
+	l := NewRateLimiter(RateLimitConfig{
+		Rate:   1,
+		Action: RateLimitDelay,
+		Clock:  clock.NewFake(time.Unix(1643367217, 0)),
+	})
+
+	device := &Event{DeviceID: "device-1"}
+
+	assert.Equal(t, 1, executeN(l, device, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	events, err := l.Execute(ctx, device.Clone())
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, events)
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	l := NewRateLimiter(RateLimitConfig{})
+
+	assert.Equal(t, 10, executeN(l, &Event{DeviceID: "device-1"}, 10))
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1643367217, 0))
+
+	l := NewRateLimiter(RateLimitConfig{
+		Rate:  1,
+		Clock: fake,
+	})
+
+	executeN(l, &Event{DeviceID: "device-1"}, 1)
+	executeN(l, &Event{DeviceID: "device-2"}, 1)
+
+	assert.Len(t, l.buckets, 2)
+
+	fake.Advance(rateLimitSweepInterval)
+
+	executeN(l, &Event{DeviceID: "device-3"}, 1)
+
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestRateLimiterClientClock(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1643367217, 0))
+
+	l := NewRateLimiter(RateLimitConfig{Rate: 1})
+
+	// The clock of the client is given once the options are applied.
+	newClient("foo", WithRateLimiter(l), WithClock(fake))
+
+	device := &Event{DeviceID: "device-1"}
+
+	assert.Equal(t, 1, executeN(l, device, 2))
+
+	fake.Advance(time.Second)
+
+	assert.Equal(t, 1, executeN(l, device, 1))
+
+	// A configured clock is kept.
+	other := clock.NewFake(time.Unix(1643367217, 0))
+
+	l = NewRateLimiter(RateLimitConfig{Rate: 1, Clock: other})
+
+	newClient("foo", WithRateLimiter(l), WithClock(fake))
+
+	assert.Same(t, other, l.config.Clock)
+}
@@ -0,0 +1,253 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+)
+
+// RedactedValue replaces the values masked by RedactMask.
+const RedactedValue = "[REDACTED]"
+
+// RedactionAction defines how a value is redacted.
+type RedactionAction int
+
+const (
+	// RedactDrop removes the value.
+	RedactDrop RedactionAction = iota
+
+	// RedactMask replaces the value with RedactedValue.
+	RedactMask
+
+	// RedactHash replaces the value with its hex encoded HMAC-SHA256.
+	RedactHash
+)
+
+// RedactionRule selects the values to redact.
+//
+// Path selects a field by its JSON name, e.g. "ip", "idfa", "event_properties.email",
+// "user_properties.address.street" or "groups.company", and supports path.Match patterns like
+// "event_properties.*email*". Pattern matches the string values of the
+// selected fields, or of all the fields when Path is empty, in which case
+// only the matching substrings are masked or hashed.
+type RedactionRule struct {
+	Path    string
+	Pattern *regexp.Regexp
+	Action  RedactionAction
+}
+
+// RedactionConfig struct.
+type RedactionConfig struct {
+	Rules []RedactionRule
+
+	// HashKey is the HMAC key of RedactHash.
+	HashKey []byte
+
+	// LocationPrecision truncates LocationLat and LocationLng to that number of decimals, 0 disables it.
+	LocationPrecision int
+}
+
+// redactionPlugin is an enrichment plugin redacting event data.
+type redactionPlugin struct {
+	config RedactionConfig
+}
+
+func (p *redactionPlugin) Type() PluginType {
+	return PluginTypeEnrichment
+}
+
+func (p *redactionPlugin) Execute(_ context.Context, event *Event) ([]*Event, error) {
+	if err := p.redact(event); err != nil {
+		return nil, err
+	}
+
+	return []*Event{event}, nil
+}
+
+func (p *redactionPlugin) redact(event *Event) error {
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{"user_id", &event.UserID},
+		{"device_id", &event.DeviceID},
+		{"ip", &event.IP},
+		{"idfa", &event.IDFA},
+		{"idfv", &event.IDFV},
+		{"adid", &event.ADID},
+		{"android_id", &event.AndroidID},
+	}
+
+	for _, field := range fields {
+		if *field.value == "" {
+			continue
+		}
+
+		value, keep := p.redactValue(field.name, *field.value)
+		if !keep {
+			*field.value = ""
+
+			continue
+		}
+
+		if s, ok := value.(string); ok {
+			*field.value = s
+		}
+	}
+
+	var err error
+
+	if event.EventProperties, err = p.redactProperties("event_properties", event.EventProperties); err != nil {
+		return err
+	}
+
+	if event.UserProperties, err = p.redactProperties("user_properties", event.UserProperties); err != nil {
+		return err
+	}
+
+	if event.Groups, err = p.redactProperties("groups", event.Groups); err != nil {
+		return err
+	}
+
+	if p.config.LocationPrecision > 0 {
+		event.LocationLat = truncate(event.LocationLat, p.config.LocationPrecision)
+		event.LocationLng = truncate(event.LocationLng, p.config.LocationPrecision)
+	}
+
+	return nil
+}
+
+func (p *redactionPlugin) redactProperties(name string, props map[string]interface{}) (map[string]interface{}, error) {
+	if len(props) == 0 {
+		return props, nil
+	}
+
+	// The properties are normalized through JSON so that values of any type
+	// are inspected and the maps of the caller are left untouched.
+	normalized, err := normalizeJSON(props)
+	if err != nil {
+		return nil, fmt.Errorf("redact %s failed: %w", name, err)
+	}
+
+	value, _ := p.redactValue(name, normalized)
+
+	redacted, _ := value.(map[string]interface{})
+
+	return redacted, nil
+}
+
+func matchPath(pattern string, name string) bool {
+	matched, err := path.Match(pattern, name)
+
+	return err == nil && matched
+}
+
+// redactValue returns the redacted value and whether it must be kept.
+func (p *redactionPlugin) redactValue(name string, value interface{}) (interface{}, bool) {
+	for _, rule := range p.config.Rules {
+		if rule.Pattern == nil && rule.Path != "" && matchPath(rule.Path, name) {
+			return p.apply(rule.Action, value)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			redacted, keep := p.redactValue(name+"."+key, item)
+			if !keep {
+				delete(v, key)
+
+				continue
+			}
+
+			v[key] = redacted
+		}
+
+		return v, true
+	case []interface{}:
+		items := make([]interface{}, 0, len(v))
+
+		for _, item := range v {
+			if redacted, keep := p.redactValue(name, item); keep {
+				items = append(items, redacted)
+			}
+		}
+
+		return items, true
+	case string:
+		return p.redactString(name, v)
+	default:
+		return value, true
+	}
+}
+
+func (p *redactionPlugin) redactString(name string, value string) (interface{}, bool) {
+	for _, rule := range p.config.Rules {
+		if rule.Pattern == nil || (rule.Path != "" && !matchPath(rule.Path, name)) {
+			continue
+		}
+
+		if !rule.Pattern.MatchString(value) {
+			continue
+		}
+
+		switch rule.Action {
+		case RedactDrop:
+			return nil, false
+		case RedactMask:
+			value = rule.Pattern.ReplaceAllLiteralString(value, RedactedValue)
+		case RedactHash:
+			value = rule.Pattern.ReplaceAllStringFunc(value, p.hash)
+		}
+	}
+
+	return value, true
+}
+
+func (p *redactionPlugin) apply(action RedactionAction, value interface{}) (interface{}, bool) {
+	switch action {
+	case RedactDrop:
+		return nil, false
+	case RedactMask:
+		return RedactedValue, true
+	case RedactHash:
+		s, ok := value.(string)
+		if !ok {
+			b, err := json.Marshal(value)
+			if err != nil {
+				return RedactedValue, true
+			}
+
+			s = string(b)
+		}
+
+		return p.hash(s), true
+	}
+
+	return nil, false
+}
+
+func (p *redactionPlugin) hash(value string) string {
+	mac := hmac.New(sha256.New, p.config.HashKey)
+
+	// hash.Hash.Write never returns an error.
+	_, _ = mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func truncate(value float64, precision int) float64 {
+	pow := math.Pow(10, float64(precision))
+
+	return math.Trunc(value*pow) / pow
+}
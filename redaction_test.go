@@ -0,0 +1,210 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var emailPattern = regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`)
+
+func newTestRedactionPlugin() *redactionPlugin {
+	return &redactionPlugin{
+		config: RedactionConfig{
+			Rules: []RedactionRule{
+				{Path: "ip", Action: RedactDrop},
+				{Path: "idfa", Action: RedactMask},
+				{Path: "adid", Action: RedactHash},
+				{Path: "event_properties.password", Action: RedactDrop},
+				{Path: "user_properties.*phone*", Action: RedactMask},
+				{Path: "user_properties.address", Action: RedactHash},
+				{Path: "groups.company", Action: RedactMask},
+				{Pattern: emailPattern, Action: RedactHash},
+				{Path: "event_properties.comment", Pattern: regexp.MustCompile(`\d{4}-\d{4}-\d{4}-\d{4}`), Action: RedactMask},
+			},
+			HashKey:           []byte("secret"),
+			LocationPrecision: 2,
+		},
+	}
+}
+
+func TestRedactionPlugin(t *testing.T) {
+	p := newTestRedactionPlugin()
+
+	assert.Equal(t, PluginTypeEnrichment, p.Type())
+
+	props := map[string]interface{}{
+		"password": "hunter2",
+		"contact":  "write to john@example.com or jane@example.com",
+		"comment":  "card 1234-5678-9012-3456 declined",
+		"nested": map[string]interface{}{
+			"emails": []string{"john@example.com"},
+		},
+		"count": 42,
+	}
+
+	events, err := p.Execute(context.Background(), &Event{
+		UserID:          "john@example.com",
+		DeviceID:        "0a16e988",
+		EventType:       "user.created",
+		IP:              "192.168.1.1",
+		IDFA:            "idfa-1",
+		ADID:            "adid-1",
+		LocationLat:     43.483152,
+		LocationLng:     -1.558626,
+		EventProperties: props,
+		UserProperties: map[string]interface{}{
+			"mobile_phone": "+33600000000",
+			"address": map[string]interface{}{
+				"street": "1 rue de la Paix",
+			},
+		},
+		Groups: map[string]interface{}{
+			"company": "Acme",
+			"owner":   "john@example.com",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	event := events[0]
+
+	johnHash := p.hash("john@example.com")
+
+	assert.Equal(t, johnHash, event.UserID)
+	assert.Equal(t, "0a16e988", event.DeviceID)
+	assert.Equal(t, "", event.IP)
+	assert.Equal(t, RedactedValue, event.IDFA)
+	assert.Equal(t, p.hash("adid-1"), event.ADID)
+	assert.Equal(t, 43.48, event.LocationLat)
+	assert.Equal(t, -1.55, event.LocationLng)
+
+	assert.NotContains(t, event.EventProperties, "password")
+	assert.Equal(t, "write to "+johnHash+" or "+p.hash("jane@example.com"), event.EventProperties["contact"])
+	assert.Equal(t, "card "+RedactedValue+" declined", event.EventProperties["comment"])
+	assert.Equal(t, map[string]interface{}{"emails": []interface{}{johnHash}}, event.EventProperties["nested"])
+
+	assert.Equal(t, RedactedValue, event.UserProperties["mobile_phone"])
+	assert.Equal(t, p.hash(`{"street":"1 rue de la Paix"}`), event.UserProperties["address"])
+
+	assert.Equal(t, map[string]interface{}{"company": RedactedValue, "owner": johnHash}, event.Groups)
+
+	// The properties of the caller are left untouched.
+	assert.Equal(t, "hunter2", props["password"])
+}
+
+func TestRedactionPluginDropPattern(t *testing.T) {
+	p := &redactionPlugin{
+		config: RedactionConfig{
+			Rules: []RedactionRule{
+				{Pattern: emailPattern, Action: RedactDrop},
+			},
+		},
+	}
+
+	events, err := p.Execute(context.Background(), &Event{
+		EventType: "user.created",
+		EventProperties: map[string]interface{}{
+			"email": "john@example.com",
+			"tags":  []interface{}{"a", "john@example.com"},
+			"plan":  "premium",
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"tags": []interface{}{"a"},
+		"plan": "premium",
+	}, events[0].EventProperties)
+}
+
+func TestRedactionPluginInvalidProperties(t *testing.T) {
+	p := newTestRedactionPlugin()
+
+	_, err := p.Execute(context.Background(), &Event{
+		EventType: "user.created",
+		EventProperties: map[string]interface{}{
+			"callback": func() {},
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestClientWithRedaction(t *testing.T) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	var body []byte
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer wg.Done()
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		defer r.Body.Close()
+
+		body = b
+	}))
+	defer ts.Close()
+
+	p := newTestRedactionPlugin()
+
+	c := New(
+		"foo",
+		WithURL(ts.URL),
+		WithInterval(time.Millisecond*100),
+		WithRedaction(p.config),
+	)
+	defer c.Close()
+
+	err := c.Enqueue(&Event{
+		UserID:      "john@example.com",
+		EventType:   "user.created",
+		Timestamp:   1643367217,
+		IP:          "192.168.1.1",
+		IDFA:        "idfa-1",
+		ADID:        "adid-1",
+		LocationLat: 43.483152,
+		LocationLng: -1.558626,
+		EventProperties: map[string]interface{}{
+			"password": "hunter2",
+			"contact":  "jane@example.com",
+		},
+		UserProperties: map[string]interface{}{
+			"phone": "+33600000000",
+		},
+	})
+	assert.NoError(t, err)
+
+	wg.Wait()
+
+	for _, raw := range []string{
+		"john@example.com",
+		"jane@example.com",
+		"192.168.1.1",
+		"idfa-1",
+		"adid-1",
+		"hunter2",
+		"+33600000000",
+		"43.483152",
+		"-1.558626",
+	} {
+		assert.NotContains(t, string(body), raw)
+	}
+
+	assert.Contains(t, string(body), p.hash("john@example.com"))
+	assert.Contains(t, string(body), `"location_lat":43.48`)
+}
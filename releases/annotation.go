@@ -0,0 +1,97 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package releases
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/internal/api"
+)
+
+// DayFormat is the layout of the dates of annotations.
+const DayFormat = "2006-01-02"
+
+const annotationsPath = "/api/2/annotations"
+
+// Annotation of the charts of a project.
+type Annotation struct {
+	ID      int64  `json:"id,omitempty"`
+	Date    string `json:"date"`
+	Label   string `json:"label"`
+	Details string `json:"details,omitempty"`
+}
+
+// Time returns the date of the annotation.
+func (a *Annotation) Time() (time.Time, error) {
+	t, err := time.Parse(DayFormat, a.Date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse annotation date failed: %w", err)
+	}
+
+	return t, nil
+}
+
+// AnnotationRequest struct.
+type AnnotationRequest struct {
+	// AppID is the ID of the project.
+	AppID int64
+
+	Date    time.Time
+	Label   string
+	Details string
+
+	// ChartID restricts the annotation to a chart, when not empty.
+	ChartID string
+}
+
+// Annotations returns the chart annotations of the project.
+func (c *Client) Annotations(ctx context.Context) ([]*Annotation, error) {
+	resp := &struct {
+		Data []*Annotation `json:"data"`
+	}{}
+
+	if err := c.api.JSON(ctx, &api.Request{Method: http.MethodGet, Path: annotationsPath}, resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// CreateAnnotation creates a chart annotation.
+func (c *Client) CreateAnnotation(ctx context.Context, req *AnnotationRequest) (*Annotation, error) {
+	query := url.Values{
+		"app_id": {strconv.FormatInt(req.AppID, 10)},
+		"date":   {req.Date.Format(DayFormat)},
+		"label":  {req.Label},
+	}
+
+	if req.Details != "" {
+		query.Set("details", req.Details)
+	}
+
+	if req.ChartID != "" {
+		query.Set("chart_id", req.ChartID)
+	}
+
+	resp := &struct {
+		Success    bool        `json:"success"`
+		Annotation *Annotation `json:"annotation"`
+	}{}
+
+	if err := c.api.JSON(ctx, &api.Request{Method: http.MethodPost, Path: annotationsPath, Query: query}, resp); err != nil {
+		return nil, err
+	}
+
+	if !resp.Success {
+		return nil, ErrRequestFailed
+	}
+
+	return resp.Annotation, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package releases
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientAnnotations(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/2/annotations", r.URL.Path)
+
+		w.Write([]byte(`{"data":[{"id":1,"date":"2026-10-19","label":"Release 1.4.0","details":"Deployed by CI"}]}`))
+	})
+	defer ts.Close()
+
+	annotations, err := c.Annotations(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*Annotation{{
+		ID:      1,
+		Date:    "2026-10-19",
+		Label:   "Release 1.4.0",
+		Details: "Deployed by CI",
+	}}, annotations)
+
+	date, err := annotations[0].Time()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), date)
+}
+
+func TestClientCreateAnnotation(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/2/annotations", r.URL.Path)
+
+		query := r.URL.Query()
+		assert.Equal(t, "12345", query.Get("app_id"))
+		assert.Equal(t, "2026-10-19", query.Get("date"))
+		assert.Equal(t, "Release 1.4.0", query.Get("label"))
+		assert.Equal(t, "abc", query.Get("chart_id"))
+		assert.NotContains(t, query, "details")
+
+		w.Write([]byte(`{"success":true,"annotation":{"id":2,"date":"2026-10-19","label":"Release 1.4.0"}}`))
+	})
+	defer ts.Close()
+
+	annotation, err := c.CreateAnnotation(context.Background(), &AnnotationRequest{
+		AppID:   12345,
+		Date:    time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC),
+		Label:   "Release 1.4.0",
+		ChartID: "abc",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &Annotation{ID: 2, Date: "2026-10-19", Label: "Release 1.4.0"}, annotation)
+}
+
+func TestClientCreateAnnotationNotSuccessful(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":false}`))
+	})
+	defer ts.Close()
+
+	_, err := c.CreateAnnotation(context.Background(), &AnnotationRequest{
+		AppID: 12345,
+		Date:  time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC),
+		Label: "Release 1.4.0",
+	})
+	assert.ErrorIs(t, err, ErrRequestFailed)
+}
+
+func TestAnnotationTimeInvalid(t *testing.T) {
+	_, err := (&Annotation{Date: "19/10/2026"}).Time()
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package releases implements a client of the Amplitude Releases and Chart Annotations APIs.
+// see: https://amplitude.com/docs/apis/analytics/releases
+// see: https://amplitude.com/docs/apis/analytics/chart-annotations
+package releases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/euskadi31/go-amplitude/internal/api"
+)
+
+const (
+	StandardEndpoint    = api.StandardEndpoint
+	EUResidencyEndpoint = api.EUResidencyEndpoint
+)
+
+// TimeFormat is the layout of the release times, in UTC.
+const TimeFormat = "2006-01-02 15:04:05"
+
+const releasePath = "/api/2/release"
+
+// ErrRequestFailed is returned when the API responds with success false.
+var ErrRequestFailed = errors.New("releases request failed")
+
+// Release struct.
+type Release struct {
+	Version     string
+	Title       string
+	Description string
+	Platforms   []string
+
+	// ReleaseStart defaults to now.
+	ReleaseStart time.Time
+
+	// ReleaseEnd defaults to ReleaseStart.
+	ReleaseEnd time.Time
+
+	CreatedBy string
+
+	// ChartVisibility shows the release on the charts.
+	ChartVisibility bool
+}
+
+func (r *Release) form() url.Values {
+	start := r.ReleaseStart
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	end := r.ReleaseEnd
+	if end.IsZero() {
+		end = start
+	}
+
+	form := url.Values{
+		"version":          {r.Version},
+		"release_start":    {start.UTC().Format(TimeFormat)},
+		"release_end":      {end.UTC().Format(TimeFormat)},
+		"title":            {r.Title},
+		"chart_visibility": {strconv.FormatBool(r.ChartVisibility)},
+	}
+
+	if r.Description != "" {
+		form.Set("description", r.Description)
+	}
+
+	if len(r.Platforms) > 0 {
+		form.Set("platforms", strings.Join(r.Platforms, ","))
+	}
+
+	if r.CreatedBy != "" {
+		form.Set("created_by", r.CreatedBy)
+	}
+
+	return form
+}
+
+// Client of the Releases and Chart Annotations APIs.
+type Client struct {
+	api *api.Client
+}
+
+// New client authenticated with the API key and secret key of the project.
+func New(apiKey string, secretKey string, opts ...Option) *Client {
+	c := &Client{
+		api: api.New(StandardEndpoint),
+	}
+
+	c.api.Authorize = api.BasicAuth(apiKey, secretKey)
+
+	api.Apply(c, c.api, opts...)
+
+	return c
+}
+
+// CreateRelease creates a release and returns its ID.
+func (c *Client) CreateRelease(ctx context.Context, release *Release) (int64, error) {
+	resp := &struct {
+		Success bool `json:"success"`
+		Release struct {
+			ID int64 `json:"id"`
+		} `json:"release"`
+	}{}
+
+	if err := c.api.JSON(ctx, api.NewFormRequest(http.MethodPost, releasePath, release.form()), resp); err != nil {
+		return 0, err
+	}
+
+	if !resp.Success {
+		return 0, ErrRequestFailed
+	}
+
+	return resp.Release.ID, nil
+}
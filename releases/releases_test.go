@@ -0,0 +1,84 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package releases
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *Client) {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "key", user)
+		assert.Equal(t, "secret", pass)
+
+		handler(w, r)
+	}))
+
+	return ts, New("key", "secret", WithURL(ts.URL), WithRetryInterval(time.Millisecond))
+}
+
+func TestClientCreateRelease(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/2/release", r.URL.Path)
+
+		assert.NoError(t, r.ParseForm())
+
+		assert.Equal(t, "1.4.0", r.PostForm.Get("version"))
+		assert.Equal(t, "2026-10-19 08:00:00", r.PostForm.Get("release_start"))
+		assert.Equal(t, "2026-10-19 08:00:00", r.PostForm.Get("release_end"))
+		assert.Equal(t, "Release 1.4.0", r.PostForm.Get("title"))
+		assert.Equal(t, "iOS,Android", r.PostForm.Get("platforms"))
+		assert.Equal(t, "ci@example.com", r.PostForm.Get("created_by"))
+		assert.Equal(t, "true", r.PostForm.Get("chart_visibility"))
+		assert.NotContains(t, r.PostForm, "description")
+
+		w.Write([]byte(`{"success":true,"release":{"id":9876,"version":"1.4.0"}}`))
+	})
+	defer ts.Close()
+
+	id, err := c.CreateRelease(context.Background(), &Release{
+		Version:         "1.4.0",
+		Title:           "Release 1.4.0",
+		Platforms:       []string{"iOS", "Android"},
+		ReleaseStart:    time.Date(2026, 10, 19, 10, 0, 0, 0, time.FixedZone("CEST", 7200)),
+		CreatedBy:       "ci@example.com",
+		ChartVisibility: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9876), id)
+}
+
+func TestClientCreateReleaseError(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`invalid release_start`))
+	})
+	defer ts.Close()
+
+	_, err := c.CreateRelease(context.Background(), &Release{Version: "1.4.0"})
+	assert.Equal(t, &amplitude.APIError{StatusCode: http.StatusBadRequest, Message: "invalid release_start"}, err)
+}
+
+func TestClientCreateReleaseNotSuccessful(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":false}`))
+	})
+	defer ts.Close()
+
+	_, err := c.CreateRelease(context.Background(), &Release{Version: "1.4.0"})
+	assert.ErrorIs(t, err, ErrRequestFailed)
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// EventMatcher reports whether an event is sent by a route.
+type EventMatcher func(event *Event) bool
+
+// MatchEventTypes matches the events of these types.
+func MatchEventTypes(eventTypes ...string) EventMatcher {
+	types := make(map[string]struct{}, len(eventTypes))
+
+	for _, eventType := range eventTypes {
+		types[eventType] = struct{}{}
+	}
+
+	return func(event *Event) bool {
+		_, ok := types[event.EventType]
+
+		return ok
+	}
+}
+
+// MatchEventProperty matches the events having the event property name,
+// equal to one of values when any.
+func MatchEventProperty(name string, values ...interface{}) EventMatcher {
+	return func(event *Event) bool {
+		return matchProperty(event.EventProperties, name, values)
+	}
+}
+
+// MatchUserProperty matches the events having the user property name,
+// equal to one of values when any.
+func MatchUserProperty(name string, values ...interface{}) EventMatcher {
+	return func(event *Event) bool {
+		return matchProperty(event.UserProperties, name, values)
+	}
+}
+
+func matchProperty(props map[string]interface{}, name string, values []interface{}) bool {
+	value, ok := props[name]
+	if !ok {
+		return false
+	}
+
+	if len(values) == 0 {
+		return true
+	}
+
+	value = normalizeNumber(value)
+
+	for _, v := range values {
+		if reflect.DeepEqual(normalizeNumber(v), value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeNumber converts the numbers to float64, so the values decoded
+// from JSON match the Go integers of the events.
+func normalizeNumber(v interface{}) interface{} {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int8:
+		return float64(n)
+	case int16:
+		return float64(n)
+	case int32:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case uint:
+		return float64(n)
+	case uint8:
+		return float64(n)
+	case uint16:
+		return float64(n)
+	case uint32:
+		return float64(n)
+	case uint64:
+		return float64(n)
+	case float32:
+		return float64(n)
+	case json.Number:
+		if f, err := n.Float64(); err == nil {
+			return f
+		}
+	}
+
+	return v
+}
+
+// Route sends the events it matches to a project.
+type Route struct {
+	// APIKey of the project.
+	APIKey string
+
+	// Options of the client of the route, its endpoint, batching, retries and plugins.
+	Options []Option
+
+	// Match selects the events of the route, all the events when nil.
+	Match EventMatcher
+}
+
+type route struct {
+	match  EventMatcher
+	client *client
+}
+
+var (
+	_ ContextClient = (*Router)(nil)
+	_ UserMapper    = (*Router)(nil)
+	_ Attributor    = (*Router)(nil)
+)
+
+// Router is a Client sending the events to one or more projects. Each route
+// has its own client, so its own batch queue and retry state.
+type Router struct {
+	routes []*route
+}
+
+// NewRouter returns a Router starting a client for each route, see New.
+func NewRouter(routes ...*Route) *Router {
+	r := &Router{
+		routes: make([]*route, 0, len(routes)),
+	}
+
+	for _, rt := range routes {
+		r.routes = append(r.routes, &route{
+			match:  rt.Match,
+			client: start(rt.APIKey, rt.Options...),
+		})
+	}
+
+	return r
+}
+
+// Enqueue sends event to the matching routes.
+func (r *Router) Enqueue(event *Event) error {
+	return r.EnqueueContext(context.Background(), event)
+}
+
+// EnqueueContext sends a copy of event to each matching route, the events
+// matching no route are dropped. The errors of the routes are joined.
+func (r *Router) EnqueueContext(ctx context.Context, event *Event) error {
+	var errs []error
+
+	for _, rt := range r.routes {
+		if rt.match != nil && !rt.match(event) {
+			continue
+		}
+
+		if err := rt.client.EnqueueContext(ctx, event.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Map sends the mappings to every route.
+func (r *Router) Map(mappings ...*UserMapping) error {
+	for _, mapping := range mappings {
+		if err := mapping.validate(); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+
+	for _, rt := range r.routes {
+		if err := rt.client.Map(mappings...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Attribute sends attribution to every route.
+func (r *Router) Attribute(attribution *Attribution) error {
+	if err := attribution.validate(); err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, rt := range r.routes {
+		if err := rt.client.Attribute(attribution); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close flushes and closes the clients of the routes.
+func (r *Router) Close() error {
+	var errs []error
+
+	for _, rt := range r.routes {
+		if err := rt.client.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchEventTypes(t *testing.T) {
+	match := MatchEventTypes("user.created", "user.deleted")
+
+	assert.True(t, match(&Event{EventType: "user.created"}))
+	assert.True(t, match(&Event{EventType: "user.deleted"}))
+	assert.False(t, match(&Event{EventType: "user.updated"}))
+}
+
+func TestMatchEventProperty(t *testing.T) {
+	match := MatchEventProperty("source")
+
+	assert.True(t, match(&Event{EventProperties: map[string]interface{}{"source": "web"}}))
+	assert.False(t, match(&Event{}))
+
+	match = MatchEventProperty("plan", "pro", []interface{}{"team"})
+
+	assert.True(t, match(&Event{EventProperties: map[string]interface{}{"plan": "pro"}}))
+	assert.True(t, match(&Event{EventProperties: map[string]interface{}{"plan": []interface{}{"team"}}}))
+	assert.False(t, match(&Event{EventProperties: map[string]interface{}{"plan": "free"}}))
+
+	match = MatchEventProperty("seats", 10)
+
+	assert.True(t, match(&Event{EventProperties: map[string]interface{}{"seats": uint8(10)}}))
+	assert.True(t, match(&Event{EventProperties: map[string]interface{}{"seats": 10.0}}))
+	assert.False(t, match(&Event{EventProperties: map[string]interface{}{"seats": 11}}))
+}
+
+func TestMatchUserProperty(t *testing.T) {
+	match := MatchUserProperty("beta", true)
+
+	assert.True(t, match(&Event{UserProperties: map[string]interface{}{"beta": true}}))
+	assert.False(t, match(&Event{UserProperties: map[string]interface{}{"beta": false}}))
+	assert.False(t, match(&Event{EventProperties: map[string]interface{}{"beta": true}}))
+}
+
+func TestRouter(t *testing.T) {
+	product := &bytes.Buffer{}
+	science := &bytes.Buffer{}
+
+	r := NewRouter(
+		&Route{
+			APIKey:  "product-key-0001",
+			Options: []Option{WithDryRun(product, DryRunNDJSON)},
+		},
+		&Route{
+			APIKey: "science-key-0002",
+			Options: []Option{
+				WithDryRun(science, DryRunNDJSON),
+				WithPlugins(NewPlugin(PluginTypeEnrichment, func(ctx context.Context, event *Event) ([]*Event, error) {
+					event.EventProperties = map[string]interface{}{"routed": true}
+
+					return []*Event{event}, nil
+				})),
+			},
+			Match: MatchEventTypes("song.played"),
+		},
+	)
+
+	event := &Event{EventType: "song.played", UserID: "user-1", Timestamp: 1643367217}
+
+	assert.NoError(t, r.Enqueue(event))
+	assert.NoError(t, r.Enqueue(&Event{EventType: "user.created", UserID: "user-1", Timestamp: 1643367217}))
+	assert.NoError(t, r.Map(&UserMapping{UserID: "user-1", GlobalUserID: "global-1"}))
+	assert.NoError(t, r.Close())
+
+	// The plugins of a route modify its copy of the event.
+	assert.Nil(t, event.EventProperties)
+
+	assert.Equal(t, `{"api_key":"****0001","mapping":[{"user_id":"user-1","global_user_id":"global-1"}]}
+{"api_key":"****0001","events":[{"user_id":"user-1","event_type":"song.played","time":1643367217},{"user_id":"user-1","event_type":"user.created","time":1643367217}]}
+`, product.String())
+
+	assert.Equal(t, `{"api_key":"****0002","mapping":[{"user_id":"user-1","global_user_id":"global-1"}]}
+{"api_key":"****0002","events":[{"user_id":"user-1","event_type":"song.played","time":1643367217,"event_properties":{"routed":true}}]}
+`, science.String())
+}
+
+func TestRouterErrors(t *testing.T) {
+	errInvalid := errors.New("invalid")
+
+	r := NewRouter(
+		&Route{
+			APIKey: "product",
+			Options: []Option{
+				WithDryRun(&bytes.Buffer{}, DryRunNDJSON),
+				WithPlugins(NewPlugin(PluginTypeBefore, func(ctx context.Context, event *Event) ([]*Event, error) {
+					return nil, errInvalid
+				})),
+			},
+		},
+		&Route{
+			APIKey:  "science",
+			Options: []Option{WithDryRun(&bytes.Buffer{}, DryRunNDJSON)},
+		},
+	)
+
+	assert.ErrorIs(t, r.Enqueue(&Event{EventType: "user.created", UserID: "user-1"}), errInvalid)
+	assert.ErrorIs(t, r.Map(&UserMapping{UserID: "user-1"}), ErrInvalidMapping)
+	assert.ErrorIs(t, r.Attribute(&Attribution{EventType: "install"}), ErrInvalidAttribution)
+
+	assert.NoError(t, r.Close())
+	assert.ErrorIs(t, r.Close(), ErrClosed)
+	assert.ErrorIs(t, r.Enqueue(&Event{EventType: "user.created", UserID: "user-1"}), ErrClosed)
+	assert.ErrorIs(t, r.Attribute(&Attribution{EventType: "install", Platform: "ios", IDFA: "idfa"}), ErrClosed)
+	assert.ErrorIs(t, r.Map(&UserMapping{UserID: "user-1", GlobalUserID: "global-1"}), ErrClosed)
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// DefaultSampleRateProperty is the event property recording the sample rate of the sampled events.
+const DefaultSampleRateProperty = "sample_rate"
+
+// SamplingConfig struct.
+type SamplingConfig struct {
+	// Rates are the fractions of the users whose events are kept, by event
+	// type, between 0 and 1: 0 drops all the events of the type. The events
+	// of the other types are all kept.
+	Rates map[string]float64
+
+	// Salt changes the users kept, the same users are kept for all the event types otherwise.
+	Salt string
+
+	// Property records the sample rate in the kept events, DefaultSampleRateProperty by default.
+	Property string
+}
+
+// Sampler is a before plugin keeping a fraction of the users of some event types.
+type Sampler struct {
+	config SamplingConfig
+}
+
+var _ Plugin = (*Sampler)(nil)
+
+// NewSampler returns a Sampler, see WithSampling. The rates must be between 0 and 1.
+func NewSampler(config SamplingConfig) (*Sampler, error) {
+	rates := make(map[string]float64, len(config.Rates))
+
+	for eventType, rate := range config.Rates {
+		if math.IsNaN(rate) || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid sample rate %v of %q: must be between 0 and 1", rate, eventType)
+		}
+
+		rates[eventType] = rate
+	}
+
+	// The rates are copied, the map of the caller can be modified.
+	config.Rates = rates
+
+	return &Sampler{
+		config: config,
+	}, nil
+}
+
+func (p *Sampler) Type() PluginType {
+	return PluginTypeBefore
+}
+
+func (p *Sampler) Execute(_ context.Context, event *Event) ([]*Event, error) {
+	rate, ok := p.config.Rates[event.EventType]
+	if !ok || rate >= 1 {
+		return []*Event{event}, nil
+	}
+
+	if rate <= 0 {
+		return nil, nil
+	}
+
+	id := event.UserID
+	if id == "" {
+		id = event.DeviceID
+	}
+
+	// The events of unknown users cannot be sampled consistently and are kept as is.
+	if id == "" {
+		return []*Event{event}, nil
+	}
+
+	if sampleValue(p.config.Salt, id) >= rate {
+		return nil, nil
+	}
+
+	property := p.config.Property
+	if property == "" {
+		property = DefaultSampleRateProperty
+	}
+
+	event.EventProperties = withProperty(event.EventProperties, property, rate)
+
+	return []*Event{event}, nil
+}
+
+// sampleValue returns the position of id in [0, 1), a user is kept by the rates above it.
+func sampleValue(salt string, id string) float64 {
+	h := fnv.New64a()
+
+	_, _ = h.Write([]byte(salt))
+	_, _ = h.Write([]byte(id))
+
+	return float64(h.Sum64()>>11) / (1 << 53)
+}
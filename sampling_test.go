@@ -0,0 +1,164 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSampler(t *testing.T) {
+	rates := map[string]float64{"page.heartbeat": 0.1, "page.scrolled": 0}
+
+	s, err := NewSampler(SamplingConfig{Rates: rates})
+	assert.NoError(t, err)
+
+	// The rates of the caller are copied.
+	rates["page.heartbeat"] = 1
+
+	assert.Equal(t, 0.1, s.config.Rates["page.heartbeat"])
+
+	for _, rate := range []float64{-0.5, 1.5, math.NaN()} {
+		_, err := NewSampler(SamplingConfig{Rates: map[string]float64{"page.heartbeat": rate}})
+		assert.Error(t, err)
+	}
+}
+
+func TestSampler(t *testing.T) {
+	p := &Sampler{
+		config: SamplingConfig{
+			Rates: map[string]float64{
+				"page.heartbeat": 0.25,
+				"page.viewed":    1,
+				"page.scrolled":  0.000001,
+			},
+		},
+	}
+
+	assert.Equal(t, PluginTypeBefore, p.Type())
+
+	kept := 0
+
+	for i := 0; i < 10000; i++ {
+		events, err := p.Execute(context.Background(), &Event{
+			EventType: "page.heartbeat",
+			UserID:    fmt.Sprintf("user-%d", i),
+		})
+		assert.NoError(t, err)
+
+		if len(events) == 1 {
+			kept++
+
+			assert.Equal(t, 0.25, events[0].EventProperties[DefaultSampleRateProperty])
+		}
+	}
+
+	assert.InDelta(t, 2500, kept, 200)
+
+	events, err := p.Execute(context.Background(), &Event{EventType: "page.viewed", UserID: "user-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Event{{EventType: "page.viewed", UserID: "user-1"}}, events)
+
+	events, err = p.Execute(context.Background(), &Event{EventType: "user.created", UserID: "user-1"})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	events, err = p.Execute(context.Background(), &Event{EventType: "page.scrolled", UserID: "user-1"})
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+
+	// The events of unknown users are kept without sample rate.
+	events, err = p.Execute(context.Background(), &Event{EventType: "page.scrolled"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Event{{EventType: "page.scrolled"}}, events)
+}
+
+func TestSamplerDropAll(t *testing.T) {
+	p, err := NewSampler(SamplingConfig{Rates: map[string]float64{"page.heartbeat": 0}})
+	assert.NoError(t, err)
+
+	events, err := p.Execute(context.Background(), &Event{EventType: "page.heartbeat", UserID: "user-1"})
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+
+	events, err = p.Execute(context.Background(), &Event{EventType: "page.heartbeat"})
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+}
+
+func TestSamplerDeterministic(t *testing.T) {
+	p := &Sampler{
+		config: SamplingConfig{
+			Rates: map[string]float64{
+				"page.heartbeat": 0.5,
+				"video.progress": 0.5,
+			},
+			Property: "sampling",
+		},
+	}
+
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("device-%d", i)
+
+		heartbeats, err := p.Execute(context.Background(), &Event{EventType: "page.heartbeat", DeviceID: id})
+		assert.NoError(t, err)
+
+		progresses, err := p.Execute(context.Background(), &Event{
+			EventType:       "video.progress",
+			DeviceID:        id,
+			EventProperties: map[string]interface{}{"percent": 50},
+		})
+		assert.NoError(t, err)
+
+		// A user is either fully in or out.
+		assert.Equal(t, len(heartbeats), len(progresses))
+
+		if len(progresses) == 1 {
+			assert.Equal(t, map[string]interface{}{"percent": 50, "sampling": 0.5}, progresses[0].EventProperties)
+		}
+	}
+}
+
+func TestSampleValue(t *testing.T) {
+	v := sampleValue("", "user-1")
+
+	assert.Equal(t, v, sampleValue("", "user-1"))
+	assert.NotEqual(t, v, sampleValue("salt", "user-1"))
+	assert.GreaterOrEqual(t, v, 0.0)
+	assert.Less(t, v, 1.0)
+}
+
+func TestClientWithSamplingSharedProperties(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	sampler, err := NewSampler(SamplingConfig{
+		Rates: map[string]float64{"page.heartbeat": 0.9999999},
+	})
+	assert.NoError(t, err)
+
+	c := New("foo", WithURL(ts.URL), WithInterval(time.Millisecond), WithSampling(sampler))
+
+	// The properties of the caller are shared by the events marshalled by the loop.
+	props := map[string]interface{}{"page": "home"}
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, c.Enqueue(&Event{EventType: "page.heartbeat", UserID: "user-1", EventProperties: props}))
+
+		// The events are spread over several flushes.
+		time.Sleep(time.Millisecond / 10)
+	}
+
+	assert.NoError(t, c.Close())
+
+	assert.Equal(t, map[string]interface{}{"page": "home"}, props)
+}
@@ -0,0 +1,441 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// JSON Schema types.
+const (
+	SchemaTypeString  = "string"
+	SchemaTypeNumber  = "number"
+	SchemaTypeInteger = "integer"
+	SchemaTypeBoolean = "boolean"
+	SchemaTypeObject  = "object"
+	SchemaTypeArray   = "array"
+	SchemaTypeNull    = "null"
+)
+
+// Schema is the subset of JSON Schema used by tracking plans.
+// see: https://json-schema.org/understanding-json-schema/reference
+type Schema struct {
+	Types                []string           `json:"-"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"-"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// Violation of a schema.
+type Violation struct {
+	Path    string
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+
+	return v.Path + ": " + v.Message
+}
+
+type schemaAlias Schema
+
+type schemaJSON struct {
+	*schemaAlias
+	Type                 json.RawMessage `json:"type,omitempty"`
+	AdditionalProperties json.RawMessage `json:"additionalProperties,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The "type" keyword accepts a string or a list of strings and
+// "additionalProperties" accepts a boolean or a schema, in which case
+// additional properties are allowed.
+func (s *Schema) UnmarshalJSON(b []byte) error {
+	aux := &schemaJSON{
+		schemaAlias: (*schemaAlias)(s),
+	}
+
+	if err := json.Unmarshal(b, aux); err != nil {
+		return fmt.Errorf("json decode schema failed: %w", err)
+	}
+
+	if len(aux.Type) > 0 {
+		var typ string
+
+		if err := json.Unmarshal(aux.Type, &typ); err == nil {
+			s.Types = []string{typ}
+		} else if err := json.Unmarshal(aux.Type, &s.Types); err != nil {
+			return fmt.Errorf("json decode schema type failed: %w", err)
+		}
+	}
+
+	if len(aux.AdditionalProperties) > 0 {
+		var allowed bool
+
+		if err := json.Unmarshal(aux.AdditionalProperties, &allowed); err == nil {
+			s.AdditionalProperties = &allowed
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	aux := &schemaJSON{
+		schemaAlias: (*schemaAlias)(s),
+	}
+
+	var err error
+
+	switch len(s.Types) {
+	case 0:
+	case 1:
+		aux.Type, err = json.Marshal(s.Types[0])
+	default:
+		aux.Type, err = json.Marshal(s.Types)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("json encode schema type failed: %w", err)
+	}
+
+	if s.AdditionalProperties != nil {
+		aux.AdditionalProperties, err = json.Marshal(*s.AdditionalProperties)
+		if err != nil {
+			return nil, fmt.Errorf("json encode schema additionalProperties failed: %w", err)
+		}
+	}
+
+	b, err := json.Marshal(aux)
+	if err != nil {
+		return nil, fmt.Errorf("json encode schema failed: %w", err)
+	}
+
+	return b, nil
+}
+
+// Compile checks the schema and prepares its patterns. It must be called
+// before Validate when the schema was not loaded with LoadTrackingPlan.
+func (s *Schema) Compile() error {
+	if s == nil {
+		return nil
+	}
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+
+		s.pattern = re
+	}
+
+	for name, prop := range s.Properties {
+		if err := prop.Compile(); err != nil {
+			return fmt.Errorf("property %q: %w", name, err)
+		}
+	}
+
+	return s.Items.Compile()
+}
+
+// HasType reports whether the schema accepts the given JSON type.
+func (s *Schema) HasType(typ string) bool {
+	for _, t := range s.Types {
+		if t == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks value against the schema and returns the violations found.
+// The value is normalized through JSON first, so any type accepted by
+// encoding/json can be validated.
+func (s *Schema) Validate(value interface{}) []Violation {
+	if s == nil {
+		return nil
+	}
+
+	normalized, err := normalizeJSON(value)
+	if err != nil {
+		return []Violation{{Message: err.Error()}}
+	}
+
+	return s.validate("", normalized)
+}
+
+func normalizeJSON(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("json encode value failed: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var normalized interface{}
+
+	if err := dec.Decode(&normalized); err != nil {
+		return nil, fmt.Errorf("json decode value failed: %w", err)
+	}
+
+	return normalized, nil
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return SchemaTypeNull
+	case bool:
+		return SchemaTypeBoolean
+	case string:
+		return SchemaTypeString
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return SchemaTypeInteger
+		}
+
+		// Numbers without fractional part are integers, e.g. 1.0 or 1e3.
+		if f, err := v.Float64(); err == nil && !math.IsInf(f, 0) && f == math.Trunc(f) {
+			return SchemaTypeInteger
+		}
+
+		return SchemaTypeNumber
+	case []interface{}:
+		return SchemaTypeArray
+	default:
+		return SchemaTypeObject
+	}
+}
+
+func (s *Schema) acceptsType(typ string) bool {
+	if len(s.Types) == 0 || s.HasType(typ) {
+		return true
+	}
+
+	// Integers are numbers too.
+	return typ == SchemaTypeInteger && s.HasType(SchemaTypeNumber)
+}
+
+func (s *Schema) validate(path string, value interface{}) []Violation {
+	typ := jsonType(value)
+
+	if !s.acceptsType(typ) {
+		return []Violation{{
+			Path:    path,
+			Message: fmt.Sprintf("expected %s, got %s", strings.Join(s.Types, " or "), typ),
+		}}
+	}
+
+	var violations []Violation
+
+	if len(s.Enum) > 0 && !s.inEnum(value) {
+		violations = append(violations, Violation{
+			Path:    path,
+			Message: "value is not one of the allowed values",
+		})
+	}
+
+	switch v := value.(type) {
+	case string:
+		violations = append(violations, s.validateString(path, v)...)
+	case json.Number:
+		violations = append(violations, s.validateNumber(path, v)...)
+	case []interface{}:
+		violations = append(violations, s.validateArray(path, v)...)
+	case map[string]interface{}:
+		violations = append(violations, s.validateObject(path, v)...)
+	}
+
+	return violations
+}
+
+func (s *Schema) inEnum(value interface{}) bool {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range s.Enum {
+		a, err := json.Marshal(allowed)
+		if err != nil {
+			continue
+		}
+
+		if bytes.Equal(a, b) {
+			return true
+		}
+
+		// Numbers may be written differently, e.g. 1 and 1.0.
+		if n, ok := value.(json.Number); ok {
+			if f, ok := allowed.(float64); ok {
+				if nf, err := n.Float64(); err == nil && nf == f {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (s *Schema) validateString(path string, value string) []Violation {
+	var violations []Violation
+
+	length := utf8.RuneCountInString(value)
+
+	if s.MinLength != nil && length < *s.MinLength {
+		violations = append(violations, Violation{
+			Path:    path,
+			Message: fmt.Sprintf("length must be >= %d", *s.MinLength),
+		})
+	}
+
+	if s.MaxLength != nil && length > *s.MaxLength {
+		violations = append(violations, Violation{
+			Path:    path,
+			Message: fmt.Sprintf("length must be <= %d", *s.MaxLength),
+		})
+	}
+
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		violations = append(violations, Violation{
+			Path:    path,
+			Message: fmt.Sprintf("does not match pattern %q", s.Pattern),
+		})
+	}
+
+	return violations
+}
+
+func (s *Schema) validateNumber(path string, value json.Number) []Violation {
+	f, err := value.Float64()
+	if err != nil {
+		return []Violation{{Path: path, Message: err.Error()}}
+	}
+
+	var violations []Violation
+
+	if s.Minimum != nil && f < *s.Minimum {
+		violations = append(violations, Violation{
+			Path:    path,
+			Message: fmt.Sprintf("must be >= %s", formatFloat(*s.Minimum)),
+		})
+	}
+
+	if s.Maximum != nil && f > *s.Maximum {
+		violations = append(violations, Violation{
+			Path:    path,
+			Message: fmt.Sprintf("must be <= %s", formatFloat(*s.Maximum)),
+		})
+	}
+
+	return violations
+}
+
+func formatFloat(f float64) string {
+	if f == math.Trunc(f) {
+		return fmt.Sprintf("%.0f", f)
+	}
+
+	return fmt.Sprintf("%g", f)
+}
+
+func (s *Schema) validateArray(path string, values []interface{}) []Violation {
+	var violations []Violation
+
+	if s.MinItems != nil && len(values) < *s.MinItems {
+		violations = append(violations, Violation{
+			Path:    path,
+			Message: fmt.Sprintf("must have at least %d items", *s.MinItems),
+		})
+	}
+
+	if s.MaxItems != nil && len(values) > *s.MaxItems {
+		violations = append(violations, Violation{
+			Path:    path,
+			Message: fmt.Sprintf("must have at most %d items", *s.MaxItems),
+		})
+	}
+
+	if s.Items != nil {
+		for i, item := range values {
+			violations = append(violations, s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item)...)
+		}
+	}
+
+	return violations
+}
+
+func (s *Schema) validateObject(path string, values map[string]interface{}) []Violation {
+	var violations []Violation
+
+	for _, name := range s.Required {
+		if _, ok := values[name]; !ok {
+			violations = append(violations, Violation{
+				Path:    joinPath(path, name),
+				Message: "is required",
+			})
+		}
+	}
+
+	names := make([]string, 0, len(values))
+
+	for name := range values {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				violations = append(violations, Violation{
+					Path:    joinPath(path, name),
+					Message: "is not allowed",
+				})
+			}
+
+			continue
+		}
+
+		violations = append(violations, prop.validate(joinPath(path, name), values[name])...)
+	}
+
+	return violations
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaUnmarshalJSON(t *testing.T) {
+	s := &Schema{}
+
+	err := json.Unmarshal([]byte(`{"type":["string","null"],"additionalProperties":false}`), s)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"string", "null"}, s.Types)
+	assert.False(t, *s.AdditionalProperties)
+
+	s = &Schema{}
+
+	err = json.Unmarshal([]byte(`{"type":"object","additionalProperties":{"type":"string"}}`), s)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"object"}, s.Types)
+	assert.Nil(t, s.AdditionalProperties)
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `{"type":"object"}`, string(b))
+}
+
+func TestSchemaCompileInvalidPattern(t *testing.T) {
+	s := &Schema{
+		Properties: map[string]*Schema{
+			"id": {
+				Pattern: "[",
+			},
+		},
+	}
+
+	assert.Error(t, s.Compile())
+}
+
+func TestJSONType(t *testing.T) {
+	assert.Equal(t, SchemaTypeInteger, jsonType(json.Number("1")))
+	assert.Equal(t, SchemaTypeInteger, jsonType(json.Number("1.0")))
+	assert.Equal(t, SchemaTypeInteger, jsonType(json.Number("1e3")))
+	assert.Equal(t, SchemaTypeInteger, jsonType(json.Number("1e20")))
+	assert.Equal(t, SchemaTypeNumber, jsonType(json.Number("1.5")))
+	assert.Equal(t, SchemaTypeNumber, jsonType(json.Number("1e400")))
+}
+
+func TestSchemaValidate(t *testing.T) {
+	minimum := 0.0
+	maxLength := 3
+	minItems := 1
+	allowed := false
+
+	s := &Schema{
+		Types: []string{SchemaTypeObject},
+		Properties: map[string]*Schema{
+			"id": {
+				Types:     []string{SchemaTypeString},
+				MaxLength: &maxLength,
+				Pattern:   "^[a-z]+$",
+			},
+			"count": {
+				Types:   []string{SchemaTypeInteger},
+				Minimum: &minimum,
+			},
+			"ratio": {
+				Types: []string{SchemaTypeNumber},
+			},
+			"tags": {
+				Types:    []string{SchemaTypeArray},
+				MinItems: &minItems,
+				Items: &Schema{
+					Types: []string{SchemaTypeString},
+					Enum:  []interface{}{"a", "b"},
+				},
+			},
+		},
+		Required:             []string{"id"},
+		AdditionalProperties: &allowed,
+	}
+
+	assert.NoError(t, s.Compile())
+
+	assert.Empty(t, s.Validate(map[string]interface{}{
+		"id":    "abc",
+		"count": 2,
+		"ratio": 3,
+		"tags":  []string{"a", "b"},
+	}))
+
+	violations := s.Validate(map[string]interface{}{
+		"id":    "ABCD",
+		"count": -1.5,
+		"ratio": "1",
+		"tags":  []string{"c"},
+		"extra": true,
+	})
+
+	msgs := make([]string, 0, len(violations))
+
+	for _, v := range violations {
+		msgs = append(msgs, v.String())
+	}
+
+	assert.Equal(t, []string{
+		"count: expected integer, got number",
+		"extra: is not allowed",
+		"id: length must be <= 3",
+		`id: does not match pattern "^[a-z]+$"`,
+		"ratio: expected number, got string",
+		"tags[0]: value is not one of the allowed values",
+	}, msgs)
+
+	violations = s.Validate(map[string]interface{}{
+		"tags": []string{},
+	})
+
+	assert.Equal(t, []Violation{
+		{Path: "id", Message: "is required"},
+		{Path: "tags", Message: "must have at least 1 items"},
+	}, violations)
+}
@@ -0,0 +1,520 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package taxonomy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/euskadi31/go-amplitude"
+)
+
+// Action of a Change.
+type Action string
+
+// Actions.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Kind of the item of a Change.
+type Kind string
+
+// Kinds.
+const (
+	KindCategory      Kind = "category"
+	KindEventType     Kind = "event_type"
+	KindEventProperty Kind = "event_property"
+	KindUserProperty  Kind = "user_property"
+)
+
+// Change to apply to the live taxonomy, only the field of its Kind is set.
+type Change struct {
+	Action        Action
+	Kind          Kind
+	Category      *Category
+	EventType     *EventType
+	EventProperty *EventProperty
+	UserProperty  *UserProperty
+}
+
+// Name returns the name of the changed item, event properties are prefixed by their event type.
+func (c *Change) Name() string {
+	switch c.Kind {
+	case KindCategory:
+		return c.Category.Name
+	case KindEventType:
+		return c.EventType.EventType
+	case KindEventProperty:
+		return c.EventProperty.EventType + "." + c.EventProperty.EventProperty
+	case KindUserProperty:
+		return c.UserProperty.UserProperty
+	}
+
+	return ""
+}
+
+func (c *Change) String() string {
+	return fmt.Sprintf("%s %s %q", c.Action, c.Kind, c.Name())
+}
+
+// Snapshot of the live taxonomy.
+type Snapshot struct {
+	Categories      []*Category
+	EventTypes      []*EventType
+	EventProperties map[string][]*EventProperty
+	UserProperties  []*UserProperty
+}
+
+// Snapshot fetches the live taxonomy, the properties of each event type are fetched one request at a time.
+func (c *Client) Snapshot(ctx context.Context) (*Snapshot, error) {
+	var err error
+
+	s := &Snapshot{
+		EventProperties: map[string][]*EventProperty{},
+	}
+
+	if s.Categories, err = c.ListCategories(ctx); err != nil {
+		return nil, err
+	}
+
+	if s.EventTypes, err = c.ListEventTypes(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, eventType := range s.EventTypes {
+		props, err := c.ListEventProperties(ctx, eventType.EventType)
+		if err != nil {
+			return nil, err
+		}
+
+		s.EventProperties[eventType.EventType] = props
+	}
+
+	if s.UserProperties, err = c.ListUserProperties(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Diff returns the changes making the live taxonomy match the tracking plan.
+// Items of the live taxonomy missing from the plan are deleted only when prune is true.
+// Changes are ordered so that they can be applied in sequence: categories, event
+// types then properties are created and updated before properties, event types
+// then categories are deleted.
+func Diff(plan *amplitude.TrackingPlan, live *Snapshot, prune bool) []*Change {
+	local := fromTrackingPlan(plan)
+
+	var creates, deletes []*Change
+
+	// Categories.
+	liveCategories := map[string]*Category{}
+
+	for _, category := range live.Categories {
+		liveCategories[category.Name] = category
+	}
+
+	localCategories := map[string]bool{}
+
+	for _, eventType := range local.EventTypes {
+		name := eventType.CategoryName()
+		if name == "" || localCategories[name] {
+			continue
+		}
+
+		localCategories[name] = true
+
+		if _, ok := liveCategories[name]; !ok {
+			creates = append(creates, &Change{Action: ActionCreate, Kind: KindCategory, Category: &Category{Name: name}})
+		}
+	}
+
+	// Event types.
+	liveEventTypes := map[string]*EventType{}
+
+	for _, eventType := range live.EventTypes {
+		liveEventTypes[eventType.EventType] = eventType
+	}
+
+	for _, eventType := range local.EventTypes {
+		current, ok := liveEventTypes[eventType.EventType]
+
+		switch {
+		case !ok:
+			creates = append(creates, &Change{Action: ActionCreate, Kind: KindEventType, EventType: eventType})
+		case current.Description != eventType.Description || current.CategoryName() != eventType.CategoryName():
+			creates = append(creates, &Change{Action: ActionUpdate, Kind: KindEventType, EventType: eventType})
+		}
+	}
+
+	// Event properties.
+	for _, eventType := range local.EventTypes {
+		liveProps := map[string]*EventProperty{}
+
+		for _, prop := range live.EventProperties[eventType.EventType] {
+			liveProps[prop.EventProperty] = prop
+		}
+
+		for _, prop := range local.EventProperties[eventType.EventType] {
+			current, ok := liveProps[prop.EventProperty]
+
+			switch {
+			case !ok:
+				creates = append(creates, &Change{Action: ActionCreate, Kind: KindEventProperty, EventProperty: prop})
+			case !equalEventProperty(current, prop):
+				creates = append(creates, &Change{Action: ActionUpdate, Kind: KindEventProperty, EventProperty: prop})
+			}
+		}
+	}
+
+	// User properties.
+	liveUserProps := map[string]*UserProperty{}
+
+	for _, prop := range live.UserProperties {
+		liveUserProps[prop.UserProperty] = prop
+	}
+
+	for _, prop := range local.UserProperties {
+		current, ok := liveUserProps[prop.UserProperty]
+
+		switch {
+		case !ok:
+			creates = append(creates, &Change{Action: ActionCreate, Kind: KindUserProperty, UserProperty: prop})
+		case !equalUserProperty(current, prop):
+			creates = append(creates, &Change{Action: ActionUpdate, Kind: KindUserProperty, UserProperty: prop})
+		}
+	}
+
+	if !prune {
+		return creates
+	}
+
+	localUserProps := map[string]bool{}
+
+	for _, prop := range local.UserProperties {
+		localUserProps[prop.UserProperty] = true
+	}
+
+	for _, prop := range sortedUserProperties(live.UserProperties) {
+		if !localUserProps[prop.UserProperty] {
+			deletes = append(deletes, &Change{Action: ActionDelete, Kind: KindUserProperty, UserProperty: prop})
+		}
+	}
+
+	localEventTypes := map[string]bool{}
+
+	for _, eventType := range local.EventTypes {
+		localEventTypes[eventType.EventType] = true
+	}
+
+	liveTypes := sortedEventTypes(live.EventTypes)
+
+	for _, eventType := range liveTypes {
+		// The properties of deleted event types are deleted with them.
+		if !localEventTypes[eventType.EventType] {
+			continue
+		}
+
+		localProps := map[string]bool{}
+
+		for _, prop := range local.EventProperties[eventType.EventType] {
+			localProps[prop.EventProperty] = true
+		}
+
+		for _, prop := range sortedEventProperties(live.EventProperties[eventType.EventType]) {
+			if !localProps[prop.EventProperty] {
+				deletes = append(deletes, &Change{Action: ActionDelete, Kind: KindEventProperty, EventProperty: prop})
+			}
+		}
+	}
+
+	for _, eventType := range liveTypes {
+		if !localEventTypes[eventType.EventType] {
+			deletes = append(deletes, &Change{Action: ActionDelete, Kind: KindEventType, EventType: eventType})
+		}
+	}
+
+	categories := append([]*Category(nil), live.Categories...)
+
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+
+	for _, category := range categories {
+		if !localCategories[category.Name] {
+			deletes = append(deletes, &Change{Action: ActionDelete, Kind: KindCategory, Category: category})
+		}
+	}
+
+	return append(creates, deletes...)
+}
+
+// Apply applies the changes in order and stops at the first error.
+// It returns the number of changes applied.
+func (c *Client) Apply(ctx context.Context, changes []*Change) (int, error) {
+	for i, change := range changes {
+		if err := c.apply(ctx, change); err != nil {
+			return i, fmt.Errorf("%s failed: %w", change, err)
+		}
+	}
+
+	return len(changes), nil
+}
+
+//nolint:gocyclo,cyclop // one case per action and kind
+func (c *Client) apply(ctx context.Context, change *Change) error {
+	switch change.Kind {
+	case KindCategory:
+		switch change.Action {
+		case ActionCreate:
+			return c.CreateCategory(ctx, change.Category.Name)
+		case ActionUpdate:
+			return c.UpdateCategory(ctx, change.Category.ID, change.Category.Name)
+		case ActionDelete:
+			return c.DeleteCategory(ctx, change.Category.ID)
+		}
+	case KindEventType:
+		switch change.Action {
+		case ActionCreate:
+			return c.CreateEventType(ctx, change.EventType)
+		case ActionUpdate:
+			return c.UpdateEventType(ctx, change.EventType.EventType, change.EventType)
+		case ActionDelete:
+			return c.DeleteEventType(ctx, change.EventType.EventType)
+		}
+	case KindEventProperty:
+		switch change.Action {
+		case ActionCreate:
+			return c.CreateEventProperty(ctx, change.EventProperty)
+		case ActionUpdate:
+			return c.UpdateEventProperty(ctx, change.EventProperty)
+		case ActionDelete:
+			return c.DeleteEventProperty(ctx, change.EventProperty.EventType, change.EventProperty.EventProperty)
+		}
+	case KindUserProperty:
+		switch change.Action {
+		case ActionCreate:
+			return c.CreateUserProperty(ctx, change.UserProperty)
+		case ActionUpdate:
+			return c.UpdateUserProperty(ctx, change.UserProperty)
+		case ActionDelete:
+			return c.DeleteUserProperty(ctx, change.UserProperty.UserProperty)
+		}
+	}
+
+	return fmt.Errorf("unsupported change %s", change)
+}
+
+// fromTrackingPlan converts a tracking plan to a sorted taxonomy. The user
+// properties are the union of the user_properties of the events, the first
+// event type in alphabetical order defining a property wins.
+func fromTrackingPlan(plan *amplitude.TrackingPlan) *Snapshot {
+	s := &Snapshot{
+		EventProperties: map[string][]*EventProperty{},
+	}
+
+	eventTypes := make([]string, 0, len(plan.Events))
+
+	for eventType := range plan.Events {
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	sort.Strings(eventTypes)
+
+	userProps := map[string]bool{}
+
+	for _, name := range eventTypes {
+		eventType := &EventType{
+			EventType: name,
+		}
+
+		s.EventTypes = append(s.EventTypes, eventType)
+
+		schema := plan.Events[name]
+		if schema == nil {
+			continue
+		}
+
+		eventType.Description = schema.Description
+
+		if schema.Category != "" {
+			eventType.Category = &Category{Name: schema.Category}
+		}
+
+		if schema.EventProperties != nil {
+			required := map[string]bool{}
+
+			for _, prop := range schema.EventProperties.Required {
+				required[prop] = true
+			}
+
+			for _, prop := range sortedKeys(schema.EventProperties.Properties) {
+				p := fromSchema(schema.EventProperties.Properties[prop])
+
+				s.EventProperties[name] = append(s.EventProperties[name], &EventProperty{
+					EventProperty: prop,
+					EventType:     name,
+					Description:   p.Description,
+					Type:          p.Type,
+					Regex:         p.Regex,
+					EnumValues:    p.EnumValues,
+					IsArrayType:   p.IsArrayType,
+					IsRequired:    required[prop],
+				})
+			}
+		}
+
+		if schema.UserProperties != nil {
+			for _, prop := range sortedKeys(schema.UserProperties.Properties) {
+				if userProps[prop] {
+					continue
+				}
+
+				userProps[prop] = true
+
+				p := fromSchema(schema.UserProperties.Properties[prop])
+				p.UserProperty = prop
+
+				s.UserProperties = append(s.UserProperties, p)
+			}
+		}
+	}
+
+	sort.Slice(s.UserProperties, func(i, j int) bool {
+		return s.UserProperties[i].UserProperty < s.UserProperties[j].UserProperty
+	})
+
+	return s
+}
+
+// fromSchema converts the JSON Schema of a property.
+func fromSchema(schema *amplitude.Schema) *UserProperty {
+	p := &UserProperty{
+		Type: TypeAny,
+	}
+
+	if schema == nil {
+		return p
+	}
+
+	p.Description = schema.Description
+
+	if schema.HasType(amplitude.SchemaTypeArray) && schema.Items != nil {
+		p.IsArrayType = true
+		schema = schema.Items
+	}
+
+	p.Type = propertyType(schema)
+	p.Regex = schema.Pattern
+
+	for _, value := range schema.Enum {
+		if s, ok := value.(string); ok {
+			p.EnumValues = append(p.EnumValues, s)
+		}
+	}
+
+	return p
+}
+
+func propertyType(schema *amplitude.Schema) string {
+	var types []string
+
+	for _, t := range schema.Types {
+		if t != amplitude.SchemaTypeNull {
+			types = append(types, t)
+		}
+	}
+
+	if len(types) != 1 {
+		return TypeAny
+	}
+
+	switch types[0] {
+	case amplitude.SchemaTypeString:
+		if len(schema.Enum) > 0 {
+			return TypeEnum
+		}
+
+		return TypeString
+	case amplitude.SchemaTypeInteger, amplitude.SchemaTypeNumber:
+		return TypeNumber
+	case amplitude.SchemaTypeBoolean:
+		return TypeBoolean
+	}
+
+	return TypeAny
+}
+
+func equalEventProperty(a *EventProperty, b *EventProperty) bool {
+	return a.Description == b.Description &&
+		a.Type == b.Type &&
+		a.Regex == b.Regex &&
+		equalStrings(a.EnumValues, b.EnumValues) &&
+		a.IsArrayType == b.IsArrayType &&
+		a.IsRequired == b.IsRequired
+}
+
+func equalUserProperty(a *UserProperty, b *UserProperty) bool {
+	return a.Description == b.Description &&
+		a.Type == b.Type &&
+		a.Regex == b.Regex &&
+		equalStrings(a.EnumValues, b.EnumValues) &&
+		a.IsArrayType == b.IsArrayType
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func sortedKeys(m map[string]*amplitude.Schema) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedEventTypes(eventTypes []*EventType) []*EventType {
+	sorted := append([]*EventType(nil), eventTypes...)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].EventType < sorted[j].EventType
+	})
+
+	return sorted
+}
+
+func sortedEventProperties(props []*EventProperty) []*EventProperty {
+	sorted := append([]*EventProperty(nil), props...)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].EventProperty < sorted[j].EventProperty
+	})
+
+	return sorted
+}
+
+func sortedUserProperties(props []*UserProperty) []*UserProperty {
+	sorted := append([]*UserProperty(nil), props...)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UserProperty < sorted[j].UserProperty
+	})
+
+	return sorted
+}
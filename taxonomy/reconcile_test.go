@@ -0,0 +1,224 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package taxonomy
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/euskadi31/go-amplitude"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadPlan(t *testing.T) *amplitude.TrackingPlan {
+	t.Helper()
+
+	plan, err := amplitude.LoadTrackingPlan(strings.NewReader(`{
+		"events": {
+			"Song Played": {
+				"description": "A song was played",
+				"category": "Music",
+				"event_properties": {
+					"type": "object",
+					"properties": {
+						"song_id": {"type": "string", "pattern": "^[a-z0-9]+$"},
+						"quality": {"type": "string", "enum": ["low", "high"]},
+						"tags": {"type": "array", "items": {"type": "string"}}
+					},
+					"required": ["song_id"]
+				},
+				"user_properties": {
+					"type": "object",
+					"properties": {
+						"plan": {"type": "string"}
+					}
+				}
+			},
+			"user.created": {
+				"event_properties": {
+					"type": "object",
+					"properties": {
+						"score": {"type": "number"}
+					}
+				},
+				"user_properties": {
+					"type": "object",
+					"properties": {
+						"plan": {"type": "integer"},
+						"verified": {"type": "boolean"}
+					}
+				}
+			}
+		}
+	}`))
+	assert.NoError(t, err)
+
+	return plan
+}
+
+func names(changes []*Change) []string {
+	out := make([]string, 0, len(changes))
+
+	for _, change := range changes {
+		out = append(out, change.String())
+	}
+
+	return out
+}
+
+func TestDiffEmpty(t *testing.T) {
+	changes := Diff(loadPlan(t), &Snapshot{}, true)
+
+	assert.Equal(t, []string{
+		`create category "Music"`,
+		`create event_type "Song Played"`,
+		`create event_type "user.created"`,
+		`create event_property "Song Played.quality"`,
+		`create event_property "Song Played.song_id"`,
+		`create event_property "Song Played.tags"`,
+		`create event_property "user.created.score"`,
+		`create user_property "plan"`,
+		`create user_property "verified"`,
+	}, names(changes))
+
+	assert.Equal(t, &EventProperty{
+		EventProperty: "quality",
+		EventType:     "Song Played",
+		Type:          TypeEnum,
+		EnumValues:    StringList{"low", "high"},
+	}, changes[3].EventProperty)
+
+	assert.Equal(t, &EventProperty{
+		EventProperty: "song_id",
+		EventType:     "Song Played",
+		Type:          TypeString,
+		Regex:         "^[a-z0-9]+$",
+		IsRequired:    true,
+	}, changes[4].EventProperty)
+
+	assert.Equal(t, &EventProperty{
+		EventProperty: "tags",
+		EventType:     "Song Played",
+		Type:          TypeString,
+		IsArrayType:   true,
+	}, changes[5].EventProperty)
+
+	// The first event type defining a user property wins.
+	assert.Equal(t, TypeString, changes[7].UserProperty.Type)
+}
+
+func live() *Snapshot {
+	return &Snapshot{
+		Categories: []*Category{
+			{ID: 1, Name: "Music"},
+			{ID: 2, Name: "Legacy"},
+		},
+		EventTypes: []*EventType{
+			{EventType: "Song Played", Category: &Category{Name: "Music"}, Description: "A song was played"},
+			{EventType: "user.created", Description: "outdated"},
+			{EventType: "Old Event"},
+		},
+		EventProperties: map[string][]*EventProperty{
+			"Song Played": {
+				{EventProperty: "song_id", EventType: "Song Played", Type: TypeString, Regex: "^[a-z0-9]+$", IsRequired: true},
+				{EventProperty: "quality", EventType: "Song Played", Type: TypeEnum, EnumValues: StringList{"low", "high"}},
+				{EventProperty: "tags", EventType: "Song Played", Type: TypeString, IsArrayType: true},
+				{EventProperty: "legacy", EventType: "Song Played", Type: TypeAny},
+			},
+			"user.created": {
+				{EventProperty: "score", EventType: "user.created", Type: TypeString},
+			},
+			"Old Event": {
+				{EventProperty: "foo", EventType: "Old Event", Type: TypeAny},
+			},
+		},
+		UserProperties: []*UserProperty{
+			{UserProperty: "plan", Type: TypeString},
+			{UserProperty: "verified", Type: TypeBoolean},
+			{UserProperty: "age", Type: TypeNumber},
+		},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	assert.Equal(t, []string{
+		`update event_type "user.created"`,
+		`update event_property "user.created.score"`,
+	}, names(Diff(loadPlan(t), live(), false)))
+
+	changes := Diff(loadPlan(t), live(), true)
+
+	assert.Equal(t, []string{
+		`update event_type "user.created"`,
+		`update event_property "user.created.score"`,
+		`delete user_property "age"`,
+		`delete event_property "Song Played.legacy"`,
+		`delete event_type "Old Event"`,
+		`delete category "Legacy"`,
+	}, names(changes))
+
+	assert.Equal(t, int64(2), changes[5].Category.ID)
+}
+
+func TestClientApply(t *testing.T) {
+	var requests []string
+
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.URL.Path == "/api/2/taxonomy/event/Old Event" {
+			w.Write([]byte(`{"success":false,"errors":[{"message":"not found"}]}`))
+
+			return
+		}
+
+		w.Write([]byte(`{"success":true}`))
+	})
+	defer ts.Close()
+
+	changes := Diff(loadPlan(t), live(), true)
+
+	n, err := c.Apply(context.Background(), changes)
+	assert.EqualError(t, err, `delete event_type "Old Event" failed: taxonomy request failed: not found`)
+	assert.Equal(t, 4, n)
+
+	assert.Equal(t, []string{
+		"PUT /api/2/taxonomy/event/user.created",
+		"PUT /api/2/taxonomy/event-property/score",
+		"DELETE /api/2/taxonomy/user-property/age",
+		"DELETE /api/2/taxonomy/event-property/legacy",
+		"DELETE /api/2/taxonomy/event/Old Event",
+	}, requests)
+}
+
+func TestClientSnapshot(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/2/taxonomy/category":
+			w.Write([]byte(`{"success":true,"data":[{"id":1,"name":"Music"}]}`))
+		case "/api/2/taxonomy/event":
+			w.Write([]byte(`{"success":true,"data":[{"event_type":"Song Played"}]}`))
+		case "/api/2/taxonomy/event-property":
+			w.Write([]byte(`{"success":true,"data":[{"event_property":"song_id","type":"string"}]}`))
+		case "/api/2/taxonomy/user-property":
+			w.Write([]byte(`{"success":true,"data":[{"user_property":"plan","type":"string"}]}`))
+		}
+	})
+	defer ts.Close()
+
+	s, err := c.Snapshot(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, &Snapshot{
+		Categories: []*Category{{ID: 1, Name: "Music"}},
+		EventTypes: []*EventType{{EventType: "Song Played"}},
+		EventProperties: map[string][]*EventProperty{
+			"Song Played": {{EventProperty: "song_id", EventType: "Song Played", Type: TypeString}},
+		},
+		UserProperties: []*UserProperty{{UserProperty: "plan", Type: TypeString}},
+	}, s)
+}
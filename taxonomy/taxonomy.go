@@ -0,0 +1,350 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package taxonomy implements a client of the Amplitude Taxonomy API.
+// see: https://amplitude.com/docs/apis/analytics/taxonomy
+package taxonomy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/euskadi31/go-amplitude/internal/api"
+)
+
+const (
+	StandardEndpoint    = api.StandardEndpoint
+	EUResidencyEndpoint = api.EUResidencyEndpoint
+)
+
+const basePath = "/api/2/taxonomy"
+
+// Property types.
+const (
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeEnum    = "enum"
+	TypeAny     = "any"
+)
+
+// Error is returned when the Taxonomy API responds with success false.
+type Error struct {
+	Messages []string
+}
+
+func (e *Error) Error() string {
+	return "taxonomy request failed: " + strings.Join(e.Messages, ", ")
+}
+
+// StringList is a list of strings encoded as a JSON array or a comma separated string.
+type StringList []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *StringList) UnmarshalJSON(b []byte) error {
+	var s string
+
+	if err := json.Unmarshal(b, &s); err == nil {
+		*l = nil
+
+		if s != "" {
+			*l = strings.Split(s, ",")
+		}
+
+		return nil
+	}
+
+	var values []string
+
+	if err := json.Unmarshal(b, &values); err != nil {
+		return fmt.Errorf("json decode string list failed: %w", err)
+	}
+
+	*l = values
+
+	return nil
+}
+
+// Category of event types.
+type Category struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// EventType struct.
+type EventType struct {
+	EventType   string    `json:"event_type"`
+	Category    *Category `json:"category,omitempty"`
+	Description string    `json:"description"`
+	DisplayName string    `json:"display_name,omitempty"`
+}
+
+// CategoryName returns the name of the category of the event type.
+func (e *EventType) CategoryName() string {
+	if e.Category == nil {
+		return ""
+	}
+
+	return e.Category.Name
+}
+
+// EventProperty struct.
+type EventProperty struct {
+	EventProperty string     `json:"event_property"`
+	EventType     string     `json:"event_type"`
+	Description   string     `json:"description"`
+	Type          string     `json:"type"`
+	Regex         string     `json:"regex"`
+	EnumValues    StringList `json:"enum_values"`
+	IsArrayType   bool       `json:"is_array_type"`
+	IsRequired    bool       `json:"is_required"`
+}
+
+func (p *EventProperty) form() url.Values {
+	form := url.Values{
+		"event_type":    {p.EventType},
+		"description":   {p.Description},
+		"type":          {p.Type},
+		"regex":         {p.Regex},
+		"enum_values":   {strings.Join(p.EnumValues, ",")},
+		"is_array_type": {strconv.FormatBool(p.IsArrayType)},
+		"is_required":   {strconv.FormatBool(p.IsRequired)},
+	}
+
+	return form
+}
+
+// UserProperty struct.
+type UserProperty struct {
+	UserProperty string     `json:"user_property"`
+	Description  string     `json:"description"`
+	Type         string     `json:"type"`
+	Regex        string     `json:"regex"`
+	EnumValues   StringList `json:"enum_values"`
+	IsArrayType  bool       `json:"is_array_type"`
+}
+
+func (p *UserProperty) form() url.Values {
+	return url.Values{
+		"description":   {p.Description},
+		"type":          {p.Type},
+		"regex":         {p.Regex},
+		"enum_values":   {strings.Join(p.EnumValues, ",")},
+		"is_array_type": {strconv.FormatBool(p.IsArrayType)},
+	}
+}
+
+// Client of the Taxonomy API.
+type Client struct {
+	api *api.Client
+}
+
+// New Taxonomy API client authenticated with the API key and secret key of the project.
+func New(apiKey string, secretKey string, opts ...Option) *Client {
+	c := &Client{
+		api: api.New(StandardEndpoint),
+	}
+
+	c.api.Authorize = api.BasicAuth(apiKey, secretKey)
+
+	api.Apply(c, c.api, opts...)
+
+	return c
+}
+
+type response struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Errors  []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (c *Client) do(ctx context.Context, req *api.Request, out interface{}) error {
+	resp := &response{}
+
+	if err := c.api.JSON(ctx, req, resp); err != nil {
+		return err
+	}
+
+	if !resp.Success {
+		e := &Error{}
+
+		for _, msg := range resp.Errors {
+			e.Messages = append(e.Messages, msg.Message)
+		}
+
+		return e
+	}
+
+	if out == nil || len(resp.Data) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		return fmt.Errorf("json decode response data failed: %w", err)
+	}
+
+	return nil
+}
+
+func path(parts ...string) string {
+	p := basePath
+
+	for _, part := range parts {
+		p += "/" + url.PathEscape(part)
+	}
+
+	return p
+}
+
+// CreateCategory creates an event category.
+func (c *Client) CreateCategory(ctx context.Context, name string) error {
+	return c.do(ctx, api.NewFormRequest(http.MethodPost, path("category"), url.Values{
+		"category_name": {name},
+	}), nil)
+}
+
+// ListCategories returns the event categories.
+func (c *Client) ListCategories(ctx context.Context) ([]*Category, error) {
+	var categories []*Category
+
+	if err := c.do(ctx, &api.Request{Method: http.MethodGet, Path: path("category")}, &categories); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// UpdateCategory renames an event category.
+func (c *Client) UpdateCategory(ctx context.Context, id int64, name string) error {
+	return c.do(ctx, api.NewFormRequest(http.MethodPut, path("category", strconv.FormatInt(id, 10)), url.Values{
+		"category_name": {name},
+	}), nil)
+}
+
+// DeleteCategory deletes an event category.
+func (c *Client) DeleteCategory(ctx context.Context, id int64) error {
+	return c.do(ctx, &api.Request{Method: http.MethodDelete, Path: path("category", strconv.FormatInt(id, 10))}, nil)
+}
+
+// CreateEventType creates an event type.
+func (c *Client) CreateEventType(ctx context.Context, eventType *EventType) error {
+	return c.do(ctx, api.NewFormRequest(http.MethodPost, path("event"), url.Values{
+		"event_type":  {eventType.EventType},
+		"category":    {eventType.CategoryName()},
+		"description": {eventType.Description},
+	}), nil)
+}
+
+// ListEventTypes returns the event types.
+func (c *Client) ListEventTypes(ctx context.Context) ([]*EventType, error) {
+	var eventTypes []*EventType
+
+	if err := c.do(ctx, &api.Request{Method: http.MethodGet, Path: path("event")}, &eventTypes); err != nil {
+		return nil, err
+	}
+
+	return eventTypes, nil
+}
+
+// UpdateEventType updates the event type named name, renaming it when update.EventType differs.
+func (c *Client) UpdateEventType(ctx context.Context, name string, update *EventType) error {
+	form := url.Values{
+		"category":    {update.CategoryName()},
+		"description": {update.Description},
+	}
+
+	if update.EventType != "" && update.EventType != name {
+		form.Set("new_event_type", update.EventType)
+	}
+
+	if update.DisplayName != "" {
+		form.Set("display_name", update.DisplayName)
+	}
+
+	return c.do(ctx, api.NewFormRequest(http.MethodPut, path("event", name), form), nil)
+}
+
+// DeleteEventType deletes an event type.
+func (c *Client) DeleteEventType(ctx context.Context, name string) error {
+	return c.do(ctx, &api.Request{Method: http.MethodDelete, Path: path("event", name)}, nil)
+}
+
+// CreateEventProperty creates an event property.
+func (c *Client) CreateEventProperty(ctx context.Context, prop *EventProperty) error {
+	form := prop.form()
+	form.Set("event_property", prop.EventProperty)
+
+	return c.do(ctx, api.NewFormRequest(http.MethodPost, path("event-property"), form), nil)
+}
+
+// ListEventProperties returns the properties of an event type.
+func (c *Client) ListEventProperties(ctx context.Context, eventType string) ([]*EventProperty, error) {
+	var props []*EventProperty
+
+	if err := c.do(ctx, &api.Request{
+		Method: http.MethodGet,
+		Path:   path("event-property"),
+		Query: url.Values{
+			"event_type": {eventType},
+		},
+	}, &props); err != nil {
+		return nil, err
+	}
+
+	for _, prop := range props {
+		if prop.EventType == "" {
+			prop.EventType = eventType
+		}
+	}
+
+	return props, nil
+}
+
+// UpdateEventProperty updates an event property.
+func (c *Client) UpdateEventProperty(ctx context.Context, prop *EventProperty) error {
+	return c.do(ctx, api.NewFormRequest(http.MethodPut, path("event-property", prop.EventProperty), prop.form()), nil)
+}
+
+// DeleteEventProperty deletes a property of an event type.
+func (c *Client) DeleteEventProperty(ctx context.Context, eventType string, name string) error {
+	return c.do(ctx, api.NewFormRequest(http.MethodDelete, path("event-property", name), url.Values{
+		"event_type": {eventType},
+	}), nil)
+}
+
+// CreateUserProperty creates a user property.
+func (c *Client) CreateUserProperty(ctx context.Context, prop *UserProperty) error {
+	form := prop.form()
+	form.Set("user_property", prop.UserProperty)
+
+	return c.do(ctx, api.NewFormRequest(http.MethodPost, path("user-property"), form), nil)
+}
+
+// ListUserProperties returns the user properties.
+func (c *Client) ListUserProperties(ctx context.Context) ([]*UserProperty, error) {
+	var props []*UserProperty
+
+	if err := c.do(ctx, &api.Request{Method: http.MethodGet, Path: path("user-property")}, &props); err != nil {
+		return nil, err
+	}
+
+	return props, nil
+}
+
+// UpdateUserProperty updates a user property.
+func (c *Client) UpdateUserProperty(ctx context.Context, prop *UserProperty) error {
+	return c.do(ctx, api.NewFormRequest(http.MethodPut, path("user-property", prop.UserProperty), prop.form()), nil)
+}
+
+// DeleteUserProperty deletes a user property.
+func (c *Client) DeleteUserProperty(ctx context.Context, name string) error {
+	return c.do(ctx, &api.Request{Method: http.MethodDelete, Path: path("user-property", name)}, nil)
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package taxonomy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *Client) {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "key", user)
+		assert.Equal(t, "secret", pass)
+
+		handler(w, r)
+	}))
+
+	return ts, New("key", "secret", WithURL(ts.URL), WithRetryInterval(time.Millisecond))
+}
+
+func TestClientCategories(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/api/2/taxonomy/category", r.URL.Path)
+			assert.Equal(t, "Music", r.PostFormValue("category_name"))
+		case http.MethodGet:
+			assert.Equal(t, "/api/2/taxonomy/category", r.URL.Path)
+
+			w.Write([]byte(`{"success":true,"data":[{"id":412,"name":"Music"}]}`))
+
+			return
+		case http.MethodPut:
+			assert.Equal(t, "/api/2/taxonomy/category/412", r.URL.Path)
+			assert.Equal(t, "Audio", r.PostFormValue("category_name"))
+		case http.MethodDelete:
+			assert.Equal(t, "/api/2/taxonomy/category/412", r.URL.Path)
+		}
+
+		w.Write([]byte(`{"success":true}`))
+	})
+	defer ts.Close()
+
+	ctx := context.Background()
+
+	assert.NoError(t, c.CreateCategory(ctx, "Music"))
+
+	categories, err := c.ListCategories(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Category{{ID: 412, Name: "Music"}}, categories)
+
+	assert.NoError(t, c.UpdateCategory(ctx, 412, "Audio"))
+	assert.NoError(t, c.DeleteCategory(ctx, 412))
+}
+
+func TestClientEventTypes(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/api/2/taxonomy/event", r.URL.Path)
+			assert.Equal(t, "Song Played", r.PostFormValue("event_type"))
+			assert.Equal(t, "Music", r.PostFormValue("category"))
+			assert.Equal(t, "A song was played", r.PostFormValue("description"))
+		case http.MethodGet:
+			w.Write([]byte(`{"success":true,"data":[{"event_type":"Song Played","category":{"name":"Music"},"description":"A song was played"}]}`))
+
+			return
+		case http.MethodPut:
+			assert.Equal(t, "/api/2/taxonomy/event/Song%20Played", r.URL.EscapedPath())
+			assert.Equal(t, "Track Played", r.PostFormValue("new_event_type"))
+		case http.MethodDelete:
+			assert.Equal(t, "/api/2/taxonomy/event/Song%20Played", r.URL.EscapedPath())
+		}
+
+		w.Write([]byte(`{"success":true}`))
+	})
+	defer ts.Close()
+
+	ctx := context.Background()
+
+	eventType := &EventType{
+		EventType:   "Song Played",
+		Category:    &Category{Name: "Music"},
+		Description: "A song was played",
+	}
+
+	assert.NoError(t, c.CreateEventType(ctx, eventType))
+
+	eventTypes, err := c.ListEventTypes(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*EventType{eventType}, eventTypes)
+
+	assert.NoError(t, c.UpdateEventType(ctx, "Song Played", &EventType{EventType: "Track Played"}))
+	assert.NoError(t, c.DeleteEventType(ctx, "Song Played"))
+}
+
+func TestClientEventProperties(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/api/2/taxonomy/event-property", r.URL.Path)
+			assert.Equal(t, "quality", r.PostFormValue("event_property"))
+			assert.Equal(t, "Song Played", r.PostFormValue("event_type"))
+			assert.Equal(t, "low,high", r.PostFormValue("enum_values"))
+			assert.Equal(t, "true", r.PostFormValue("is_required"))
+		case http.MethodGet:
+			assert.Equal(t, "Song Played", r.URL.Query().Get("event_type"))
+
+			w.Write([]byte(`{"success":true,"data":[{"event_property":"quality","type":"enum","enum_values":"low,high","is_required":true}]}`))
+
+			return
+		case http.MethodPut:
+			assert.Equal(t, "/api/2/taxonomy/event-property/quality", r.URL.Path)
+			assert.Equal(t, "Song Played", r.PostFormValue("event_type"))
+		case http.MethodDelete:
+			assert.Equal(t, "/api/2/taxonomy/event-property/quality", r.URL.Path)
+			assert.Empty(t, r.URL.RawQuery)
+
+			// ParseForm ignores the body of DELETE requests.
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+
+			form, err := url.ParseQuery(string(body))
+			assert.NoError(t, err)
+			assert.Equal(t, "Song Played", form.Get("event_type"))
+		}
+
+		w.Write([]byte(`{"success":true}`))
+	})
+	defer ts.Close()
+
+	ctx := context.Background()
+
+	prop := &EventProperty{
+		EventProperty: "quality",
+		EventType:     "Song Played",
+		Type:          TypeEnum,
+		EnumValues:    StringList{"low", "high"},
+		IsRequired:    true,
+	}
+
+	assert.NoError(t, c.CreateEventProperty(ctx, prop))
+
+	props, err := c.ListEventProperties(ctx, "Song Played")
+	assert.NoError(t, err)
+	assert.Equal(t, []*EventProperty{prop}, props)
+
+	assert.NoError(t, c.UpdateEventProperty(ctx, prop))
+	assert.NoError(t, c.DeleteEventProperty(ctx, "Song Played", "quality"))
+}
+
+func TestClientUserProperties(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/api/2/taxonomy/user-property", r.URL.Path)
+			assert.Equal(t, "plan", r.PostFormValue("user_property"))
+		case http.MethodGet:
+			w.Write([]byte(`{"success":true,"data":[{"user_property":"plan","type":"string","enum_values":["free","premium"]}]}`))
+
+			return
+		case http.MethodPut, http.MethodDelete:
+			assert.Equal(t, "/api/2/taxonomy/user-property/plan", r.URL.Path)
+		}
+
+		w.Write([]byte(`{"success":true}`))
+	})
+	defer ts.Close()
+
+	ctx := context.Background()
+
+	prop := &UserProperty{
+		UserProperty: "plan",
+		Type:         TypeString,
+		EnumValues:   StringList{"free", "premium"},
+	}
+
+	assert.NoError(t, c.CreateUserProperty(ctx, prop))
+
+	props, err := c.ListUserProperties(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*UserProperty{prop}, props)
+
+	assert.NoError(t, c.UpdateUserProperty(ctx, prop))
+	assert.NoError(t, c.DeleteUserProperty(ctx, "plan"))
+}
+
+func TestClientError(t *testing.T) {
+	ts, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":false,"errors":[{"message":"Attempted to add an event type that already exists."}]}`))
+	})
+	defer ts.Close()
+
+	err := c.CreateEventType(context.Background(), &EventType{EventType: "Song Played"})
+
+	var taxonomyErr *Error
+
+	assert.True(t, errors.As(err, &taxonomyErr))
+	assert.Equal(t, []string{"Attempted to add an event type that already exists."}, taxonomyErr.Messages)
+	assert.EqualError(t, err, "taxonomy request failed: Attempted to add an event type that already exists.")
+}
+
+func TestStringList(t *testing.T) {
+	var l StringList
+
+	assert.NoError(t, json.Unmarshal([]byte(`""`), &l))
+	assert.Nil(t, l)
+
+	assert.Error(t, json.Unmarshal([]byte(`1`), &l))
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PlanViolationsProperty is the event property set by ValidationTag.
+const PlanViolationsProperty = "plan_violations"
+
+// ValidationMode defines what happens to events that do not conform to the tracking plan.
+type ValidationMode int
+
+const (
+	// ValidationReject makes Enqueue return a *ValidationError.
+	ValidationReject ValidationMode = iota
+
+	// ValidationWarn logs the violations and sends the event.
+	ValidationWarn
+
+	// ValidationTag sends the event with its violations in the PlanViolationsProperty event property.
+	ValidationTag
+)
+
+// TrackingPlan struct.
+// The JSON representation lists a JSON Schema of event_properties and user_properties per event type.
+type TrackingPlan struct {
+	Branch  string                  `json:"branch,omitempty"`
+	Source  string                  `json:"source,omitempty"`
+	Version string                  `json:"version,omitempty"`
+	Events  map[string]*EventSchema `json:"events"`
+}
+
+// EventSchema struct.
+type EventSchema struct {
+	Description     string  `json:"description,omitempty"`
+	Category        string  `json:"category,omitempty"`
+	EventProperties *Schema `json:"event_properties,omitempty"`
+	UserProperties  *Schema `json:"user_properties,omitempty"`
+}
+
+// LoadTrackingPlan decodes a JSON tracking plan.
+func LoadTrackingPlan(r io.Reader) (*TrackingPlan, error) {
+	plan := &TrackingPlan{}
+
+	if err := json.NewDecoder(r).Decode(plan); err != nil {
+		return nil, fmt.Errorf("json decode tracking plan failed: %w", err)
+	}
+
+	if err := plan.Compile(); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// LoadTrackingPlanFile decodes a JSON tracking plan file.
+func LoadTrackingPlanFile(filename string) (*TrackingPlan, error) {
+	f, err := os.Open(filename) //nolint:gosec // filename is configured by the library consumer, not user input
+	if err != nil {
+		return nil, fmt.Errorf("open tracking plan failed: %w", err)
+	}
+
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Error().Err(err).Msg("close tracking plan failed")
+		}
+	}()
+
+	return LoadTrackingPlan(f)
+}
+
+// Compile checks the schemas of the plan, it is called by LoadTrackingPlan.
+func (p *TrackingPlan) Compile() error {
+	for eventType, schema := range p.Events {
+		if schema == nil {
+			continue
+		}
+
+		if err := schema.EventProperties.Compile(); err != nil {
+			return fmt.Errorf("tracking plan %q event_properties: %w", eventType, err)
+		}
+
+		if err := schema.UserProperties.Compile(); err != nil {
+			return fmt.Errorf("tracking plan %q user_properties: %w", eventType, err)
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns the Plan sent with conforming events.
+func (p *TrackingPlan) Metadata() *Plan {
+	return &Plan{
+		Branch:  p.Branch,
+		Source:  p.Source,
+		Version: p.Version,
+	}
+}
+
+// Validate returns the violations of event against the plan.
+func (p *TrackingPlan) Validate(event *Event) []Violation {
+	schema, ok := p.Events[event.EventType]
+	if !ok {
+		return []Violation{{Message: fmt.Sprintf("event type %q is not in the tracking plan", event.EventType)}}
+	}
+
+	if schema == nil {
+		return nil
+	}
+
+	var violations []Violation
+
+	if schema.EventProperties != nil {
+		props := event.EventProperties
+		if props == nil {
+			props = map[string]interface{}{}
+		}
+
+		for _, v := range schema.EventProperties.Validate(props) {
+			v.Path = joinPath("event_properties", v.Path)
+			violations = append(violations, v)
+		}
+	}
+
+	if schema.UserProperties != nil {
+		for _, v := range schema.UserProperties.Validate(userPropertiesValues(event.UserProperties)) {
+			v.Path = joinPath("user_properties", v.Path)
+			violations = append(violations, v)
+		}
+	}
+
+	return violations
+}
+
+// userPropertiesValues returns the values set by user properties. When the
+// properties use identify operations ($set, $setOnce, ...) only the values of
+// $set and $setOnce are validated.
+func userPropertiesValues(props map[string]interface{}) map[string]interface{} {
+	values := map[string]interface{}{}
+
+	for key, value := range props {
+		if !strings.HasPrefix(key, "$") {
+			values[key] = value
+
+			continue
+		}
+
+		if key != "$set" && key != "$setOnce" {
+			continue
+		}
+
+		if m, ok := value.(map[string]interface{}); ok {
+			for k, v := range m {
+				values[k] = v
+			}
+		}
+	}
+
+	return values
+}
+
+// ValidationError is returned by Enqueue when an event does not conform to the tracking plan.
+type ValidationError struct {
+	EventType  string
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.String())
+	}
+
+	return fmt.Sprintf("event %q does not conform to the tracking plan: %s", e.EventType, strings.Join(msgs, ", "))
+}
+
+// Unwrap returns ErrInvalidEvent.
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidEvent
+}
+
+// planValidator is an enrichment plugin validating events against a tracking plan.
+type planValidator struct {
+	plan *TrackingPlan
+	mode ValidationMode
+}
+
+func (v *planValidator) Type() PluginType {
+	return PluginTypeEnrichment
+}
+
+func (v *planValidator) Execute(_ context.Context, event *Event) ([]*Event, error) {
+	if err := v.validate(event); err != nil {
+		return nil, err
+	}
+
+	return []*Event{event}, nil
+}
+
+func (v *planValidator) validate(event *Event) error {
+	violations := v.plan.Validate(event)
+
+	if len(violations) == 0 {
+		if event.Plan == nil {
+			event.Plan = v.plan.Metadata()
+		}
+
+		return nil
+	}
+
+	msgs := make([]string, 0, len(violations))
+
+	for _, violation := range violations {
+		msgs = append(msgs, violation.String())
+	}
+
+	switch v.mode {
+	case ValidationReject:
+		return &ValidationError{
+			EventType:  event.EventType,
+			Violations: violations,
+		}
+	case ValidationWarn:
+		log.Warn().Str("event_type", event.EventType).Strs("violations", msgs).Msg("event does not conform to the tracking plan")
+	case ValidationTag:
+		event.EventProperties = withProperty(event.EventProperties, PlanViolationsProperty, msgs)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTrackingPlanFile(t *testing.T) {
+	plan, err := LoadTrackingPlanFile("testdata/tracking_plan.json")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "main", plan.Branch)
+	assert.Equal(t, "backend", plan.Source)
+	assert.Equal(t, "3", plan.Version)
+	assert.Len(t, plan.Events, 2)
+	assert.Equal(t, "A song was played.", plan.Events["Song Played"].Description)
+	assert.Equal(t, &Plan{Branch: "main", Source: "backend", Version: "3"}, plan.Metadata())
+}
+
+func TestLoadTrackingPlanFileNotFound(t *testing.T) {
+	_, err := LoadTrackingPlanFile("testdata/not_found.json")
+	assert.Error(t, err)
+}
+
+func TestLoadTrackingPlanInvalid(t *testing.T) {
+	_, err := LoadTrackingPlan(strings.NewReader(`{"events":`))
+	assert.Error(t, err)
+
+	_, err = LoadTrackingPlan(strings.NewReader(`{"events":{"foo":{"event_properties":{"pattern":"["}}}}`))
+	assert.Error(t, err)
+}
+
+func TestTrackingPlanValidate(t *testing.T) {
+	plan, err := LoadTrackingPlanFile("testdata/tracking_plan.json")
+	assert.NoError(t, err)
+
+	assert.Empty(t, plan.Validate(&Event{
+		EventType: "Song Played",
+		EventProperties: map[string]interface{}{
+			"song_id":  "abc-123",
+			"duration": 180,
+			"quality":  "high",
+		},
+		UserProperties: map[string]interface{}{
+			"$set": map[string]interface{}{
+				"plan": "premium",
+			},
+			"$add": map[string]interface{}{
+				"songs": 1,
+			},
+		},
+	}))
+
+	assert.Equal(t, []Violation{
+		{Path: "event_properties.song_id", Message: "is required"},
+		{Path: "event_properties.duration", Message: "expected integer, got string"},
+		{Path: "user_properties.plan", Message: "expected string, got boolean"},
+	}, plan.Validate(&Event{
+		EventType: "Song Played",
+		EventProperties: map[string]interface{}{
+			"duration": "180",
+		},
+		UserProperties: map[string]interface{}{
+			"plan": true,
+		},
+	}))
+
+	assert.Equal(t, []Violation{
+		{Message: `event type "unknown" is not in the tracking plan`},
+	}, plan.Validate(&Event{
+		EventType: "unknown",
+	}))
+}
+
+func TestPlanValidator(t *testing.T) {
+	plan, err := LoadTrackingPlanFile("testdata/tracking_plan.json")
+	assert.NoError(t, err)
+
+	v := &planValidator{
+		plan: plan,
+		mode: ValidationReject,
+	}
+
+	event := &Event{
+		EventType: "user.created",
+	}
+
+	assert.NoError(t, v.validate(event))
+	assert.Equal(t, plan.Metadata(), event.Plan)
+
+	event = &Event{
+		EventType: "user.created",
+		EventProperties: map[string]interface{}{
+			"score": 2,
+		},
+	}
+
+	err = v.validate(event)
+	assert.True(t, errors.Is(err, ErrInvalidEvent))
+	assert.EqualError(t, err, `event "user.created" does not conform to the tracking plan: event_properties.score: must be <= 1`)
+	assert.Nil(t, event.Plan)
+
+	v.mode = ValidationWarn
+
+	assert.NoError(t, v.validate(event))
+	assert.Nil(t, event.Plan)
+
+	v.mode = ValidationTag
+
+	props := event.EventProperties
+
+	assert.NoError(t, v.validate(event))
+	assert.Nil(t, event.Plan)
+	assert.Equal(t, []string{"event_properties.score: must be <= 1"}, event.EventProperties[PlanViolationsProperty])
+
+	// The properties of the caller are left untouched.
+	assert.Equal(t, map[string]interface{}{"score": 2}, props)
+}
+
+func TestClientWithTrackingPlanSharedProperties(t *testing.T) {
+	plan, err := LoadTrackingPlanFile("testdata/tracking_plan.json")
+	assert.NoError(t, err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	c := New("foo", WithURL(ts.URL), WithInterval(time.Millisecond), WithTrackingPlan(plan, ValidationTag))
+
+	// The properties of the caller are shared by the events marshalled by the loop.
+	props := map[string]interface{}{"score": 2}
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, c.Enqueue(&Event{EventType: "user.created", UserID: "user-1", EventProperties: props}))
+
+		// The events are spread over several flushes.
+		time.Sleep(time.Millisecond / 10)
+	}
+
+	assert.NoError(t, c.Close())
+
+	assert.Equal(t, map[string]interface{}{"score": 2}, props)
+}
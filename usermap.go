@@ -0,0 +1,130 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Endpoints of the usermap API.
+// see: https://amplitude.com/docs/apis/analytics/user-mapping
+const (
+	StandardUserMapEndpoint    = "https://api.amplitude.com/usermap"
+	EUResidencyUserMapEndpoint = "https://api.eu.amplitude.com/usermap"
+)
+
+// ErrInvalidMapping is returned by Map for mappings without UserID, or without GlobalUserID when not unmapping.
+var ErrInvalidMapping = errors.New("invalid user mapping")
+
+// UserMapping maps UserID to GlobalUserID, or removes the mapping of UserID when Unmap is true.
+type UserMapping struct {
+	UserID       string `json:"user_id"`
+	GlobalUserID string `json:"global_user_id,omitempty"`
+	Unmap        bool   `json:"unmap,omitempty"`
+}
+
+func (m *UserMapping) validate() error {
+	if m.UserID == "" {
+		return fmt.Errorf("%w: user_id is required", ErrInvalidMapping)
+	}
+
+	if !m.Unmap && m.GlobalUserID == "" {
+		return fmt.Errorf("%w: global_user_id of %q is required", ErrInvalidMapping, m.UserID)
+	}
+
+	return nil
+}
+
+// userMapURLFor returns the usermap endpoint of the region of the HTTP API endpoint.
+func userMapURLFor(endpoint string) string {
+	if endpoint == EUResidencyEndpoint {
+		return EUResidencyUserMapEndpoint
+	}
+
+	return StandardUserMapEndpoint
+}
+
+// Map enqueues user mappings, they are sent in batches with the events.
+func (c *client) Map(mappings ...*UserMapping) (err error) {
+	for _, mapping := range mappings {
+		if err := mapping.validate(); err != nil {
+			return err
+		}
+	}
+
+	defer func() {
+		// See EnqueueContext.
+		if recover() != nil {
+			err = ErrClosed
+		}
+	}()
+
+	for _, mapping := range mappings {
+		c.mappingMsgs <- mapping
+	}
+
+	return nil
+}
+
+func (c *client) addMapping(mapping *UserMapping) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.mappings = append(c.mappings, mapping)
+
+	if len(c.mappings) == c.bufferSize {
+		select {
+		case c.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (c *client) getBatchMappings() []*UserMapping {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	end := c.batchSize
+	if length := len(c.mappings); length < end {
+		end = length
+	}
+
+	var mappings []*UserMapping
+
+	mappings, c.mappings = c.mappings[0:end], c.mappings[end:]
+
+	return mappings
+}
+
+func (c *client) flushMappings() error {
+	mappings := c.getBatchMappings()
+
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	b, err := json.Marshal(mappings)
+	if err != nil {
+		return fmt.Errorf("json marshal user mappings failed: %w", err)
+	}
+
+	form := url.Values{
+		"api_key": {c.key},
+		"mapping": {string(b)},
+	}
+
+	c.deliver(&Payload{
+		Kind:        PayloadUserMap,
+		URL:         c.userMapURL,
+		ContentType: "application/x-www-form-urlencoded",
+		Body:        []byte(form.Encode()),
+		Size:        len(mappings),
+	})
+
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Axel Etcheverry. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package amplitude
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserMapURLFor(t *testing.T) {
+	assert.Equal(t, StandardUserMapEndpoint, userMapURLFor(StandardEndpoint))
+	assert.Equal(t, EUResidencyUserMapEndpoint, userMapURLFor(EUResidencyEndpoint))
+
+	c := New("foo", WithURL(EUResidencyEndpoint)).(*client)
+	defer c.Close()
+
+	assert.Equal(t, EUResidencyUserMapEndpoint, c.userMapURL)
+}
+
+func TestClientMap(t *testing.T) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/usermap", r.URL.Path)
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "foo", r.PostForm.Get("api_key"))
+		assert.JSONEq(t, `[{"user_id":"user-1","global_user_id":"global-1"},{"user_id":"user-2","unmap":true}]`, r.PostForm.Get("mapping"))
+	}))
+	defer ts.Close()
+
+	var payloads []*Payload
+
+	c := start(
+		"foo",
+		WithURL(ts.URL+"/2/httpapi"),
+		WithUserMapURL(ts.URL+"/usermap"),
+		WithInterval(time.Millisecond*100),
+		WithCallback(func(payload *Payload, err error) {
+			defer wg.Done()
+
+			assert.NoError(t, err)
+
+			payloads = append(payloads, payload)
+		}),
+	)
+
+	assert.NoError(t, c.Map(
+		&UserMapping{UserID: "user-1", GlobalUserID: "global-1"},
+		&UserMapping{UserID: "user-2", Unmap: true},
+	))
+
+	wg.Wait()
+
+	assert.NoError(t, c.Close())
+
+	assert.Len(t, payloads, 1)
+	assert.Equal(t, PayloadUserMap, payloads[0].Kind)
+	assert.Equal(t, 2, payloads[0].Size)
+
+	assert.ErrorIs(t, c.Map(&UserMapping{UserID: "user-1", GlobalUserID: "global-1"}), ErrClosed)
+}
+
+func TestClientMapInvalid(t *testing.T) {
+	c := start("foo")
+	defer c.Close()
+
+	assert.ErrorIs(t, c.Map(&UserMapping{GlobalUserID: "global-1"}), ErrInvalidMapping)
+	assert.ErrorIs(t, c.Map(&UserMapping{UserID: "user-1"}), ErrInvalidMapping)
+}
+
+func TestClientMapDropped(t *testing.T) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("invalid api_key"))
+	}))
+	defer ts.Close()
+
+	var deliveryErr error
+
+	c := start(
+		"foo",
+		WithUserMapURL(ts.URL),
+		WithInterval(time.Millisecond*100),
+		WithMaxRetry(1),
+		WithCallback(func(payload *Payload, err error) {
+			defer wg.Done()
+
+			assert.Equal(t, 2, payload.Attempts)
+
+			deliveryErr = err
+		}),
+	)
+	defer c.Close()
+
+	assert.NoError(t, c.Map(&UserMapping{UserID: "user-1", GlobalUserID: "global-1"}))
+
+	wg.Wait()
+
+	assert.ErrorIs(t, deliveryErr, ErrBatchFailed)
+
+	var apiErr *APIError
+
+	assert.True(t, errors.As(deliveryErr, &apiErr))
+	assert.Equal(t, &APIError{StatusCode: http.StatusBadRequest, Message: "invalid api_key"}, apiErr)
+}
+
+func TestClientDeliveryErrorResponse(t *testing.T) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+
+		json.NewEncoder(w).Encode(&ErrorResponse{
+			Code:         http.StatusBadRequest,
+			ErrorMessage: "Request missing required field",
+			MissingField: "api_key",
+		})
+	}))
+	defer ts.Close()
+
+	var deliveryErr error
+
+	c := New(
+		"",
+		WithURL(ts.URL),
+		WithInterval(time.Millisecond*100),
+		WithMaxRetry(0),
+		WithCallback(func(payload *Payload, err error) {
+			defer wg.Done()
+
+			assert.Equal(t, PayloadEvents, payload.Kind)
+
+			deliveryErr = err
+		}),
+	)
+	defer c.Close()
+
+	assert.NoError(t, c.Enqueue(&Event{EventType: "user.created", UserID: "user-1"}))
+
+	wg.Wait()
+
+	var errorResponse *ErrorResponse
+
+	assert.True(t, errors.As(deliveryErr, &errorResponse))
+	assert.Equal(t, "api_key", errorResponse.MissingField)
+}